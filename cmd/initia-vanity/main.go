@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/degenhousedefi/initia-vanity/internal/config"
@@ -15,14 +17,22 @@ var cfg *config.Config
 
 func main() {
 	rootCmd := &cobra.Command{
-		Use:   "initia-vanity [pattern]",
+		Use:   "initia-vanity [pattern...]",
 		Short: "Generate vanity addresses for Initia",
 		Long: `Initia Vanity Address Generator
 
 A tool to generate custom Initia's cosmos based public key that match specific patterns.
 The generator supports searching for patterns at the start, end, or anywhere in the address.
-All generated addresses will start with 'init1'.`,
-		Args: cobra.MaximumNArgs(1),
+Patterns may use wildcards: '?' matches any character, [acde] or [0-9]
+matches one character from a class, and {n} repeats the previous element.
+Pattern classes match a shape instead of a literal: repeat:N, palindrome:N,
+ascending:N (consecutive characters of the bech32 alphabet) and digits:N.
+Several patterns can be searched for in a single run, each written as
+value[,position[,count]] on the command line or in a patterns file.
+Account addresses start with 'init1'. Other --mode values search
+initvaloper1 and initvalcons1 addresses, hex node IDs, multisig and xpub
+child accounts, or contract addresses in bech32 or --encoding hex.`,
+		Args: cobra.ArbitraryArgs,
 		RunE: run,
 		Example: `  # Generate an address ending with "alice"
   initia-vanity -p end alice
@@ -39,6 +49,78 @@ All generated addresses will start with 'init1'.`,
   # Generate address using specific mnemonic
   initia-vanity -p end --use-mnemonic --mnemonic "your twelve words here" alice
 
+  # Generate a 12-word mnemonic instead of 24 words
+  initia-vanity -p end --use-mnemonic --words 12 alice
+
+  # Generate a Japanese mnemonic
+  initia-vanity -p end --use-mnemonic --language japanese alice
+
+  # Mix 100 dice rolls into every key
+  initia-vanity -p end --use-mnemonic --entropy-dice 3164255265... alice
+
+  # Reproduce the mnemonic of a dice ceremony for auditing
+  initia-vanity --entropy-audit --entropy-dice 3164255265...
+
+  # Search for several patterns at once, each with its own position and count
+  initia-vanity alice,start bob,end,2 carol
+
+  # Load a team roster from a patterns file
+  initia-vanity --patterns-file roster.txt
+
+  # Use wildcards and character classes
+  initia-vanity -p start 'dew??'
+  initia-vanity -p end '[02468]{5}'
+
+  # Use pattern classes: six repeated characters, or a palindrome at the end
+  initia-vanity -p any repeat:6
+  initia-vanity -p end palindrome:7
+
+  # Accept one typo in a long name, closest matches first
+  initia-vanity -p start -c 5 --max-distance 1 --metric levenshtein dewmudgas
+
+  # Search for lookalike spellings of a name that bech32 can't spell
+  initia-vanity -p end --lookalikes alice
+
+  # Screen out addresses containing blocked words
+  initia-vanity -p start --exclude scam --denylist-file blocked.txt dew
+
+  # Require both a prefix and a suffix
+  initia-vanity --prefix dew --suffix dew
+
+  # Keep the 10 closest matches to a long name found within an hour
+  initia-vanity -p start --best 10 --duration 1h dewmudgas
+
+  # Find a seed for a Move named object whose 0x address starts with cafe
+  initia-vanity --mode object --creator init1... --encoding hex -p start cafe
+
+  # Find a CREATE2 salt for a contract address with four leading zero nibbles
+  initia-vanity --mode create2 --deployer 0x... --init-code-hash 0x... --encoding hex -p start 0000
+
+  # Find a deployer key whose first or second contract lands at 0xc0ffee...
+  initia-vanity --mode create --max-nonce 1 --encoding hex -p start c0ffee
+
+  # Keep the 3 CREATE2 salts with the most leading zero bytes, stopping at 4
+  initia-vanity --mode create2 --deployer 0x... --init-code-hash 0x... --best 3 --score zero-bytes --target 4
+
+  # Find a validator operator key whose initvaloper1 and init1 addresses both start with dew
+  initia-vanity --mode valoper --match-account -p start dew
+
+  # Find a consensus key whose initvalcons1 address starts with dew, saved as
+  # keys/<address>/priv_validator_key.json
+  initia-vanity --mode valcons --key-dir keys -p start dew
+
+  # Find a node key whose node ID starts with cafe, saved as keys/<id>/node_key.json
+  initia-vanity --mode node --key-dir keys -p start cafe
+
+  # Find the third member key of a 2-of-3 multisig whose address starts with dao
+  initia-vanity --mode multisig --threshold 2 --member <pubkey> --member <pubkey> -p start dao
+
+  # Find the first child index of an xpub whose address starts with dew, watch-only
+  initia-vanity --mode xpub --xpub xpub... -p start dew
+
+  # Find 5 addresses containing any English word of 5+ letters
+  initia-vanity --dictionary words.txt --min-word-length 5 -c 5
+
   # Save results to a JSON file
   initia-vanity -p any --format json -o addresses.json alice
 
@@ -58,12 +140,100 @@ All generated addresses will start with 'init1'.`,
 		"Enable case-sensitive pattern matching")
 	rootCmd.Flags().IntVarP(&cfg.Count, "count", "c", cfg.Count,
 		"Number of matching addresses to generate")
+	rootCmd.Flags().StringVar(&cfg.PatternsFile, "patterns-file", cfg.PatternsFile,
+		"File with one pattern per line, written as value[,position[,count]]")
+	rootCmd.Flags().StringArrayVar(&cfg.Prefixes, "prefix", cfg.Prefixes,
+		"Require the address to start with this pattern (repeatable)")
+	rootCmd.Flags().StringArrayVar(&cfg.Suffixes, "suffix", cfg.Suffixes,
+		"Require the address to end with this pattern (repeatable)")
+	rootCmd.Flags().StringArrayVar(&cfg.Contains, "contains", cfg.Contains,
+		"Require the address to contain this pattern (repeatable)")
+	rootCmd.Flags().StringVar(&cfg.Match, "match", cfg.Match,
+		"How --prefix, --suffix and --contains combine (one of: all, any)")
+	rootCmd.Flags().IntVar(&cfg.MaxDistance, "max-distance", cfg.MaxDistance,
+		"Accept addresses within this many edits of the pattern at its position")
+	rootCmd.Flags().StringVar(&cfg.Metric, "metric", cfg.Metric,
+		"Distance metric for --max-distance (one of: hamming, levenshtein)")
+	rootCmd.Flags().BoolVar(&cfg.Lookalikes, "lookalikes", cfg.Lookalikes,
+		"Search for every bech32-legal lookalike spelling of the patterns, e.g. alice -> a7lce")
+	rootCmd.Flags().StringArrayVar(&cfg.LookalikeMap, "lookalike-map", cfg.LookalikeMap,
+		"Override a lookalike substitution as char=substitutes, e.g. i=l7; - or nothing drops the character, e.g. i=- (repeatable)")
+	rootCmd.Flags().StringArrayVar(&cfg.Excludes, "exclude", cfg.Excludes,
+		"Reject addresses containing this substring (repeatable)")
+	rootCmd.Flags().StringVar(&cfg.DenylistFile, "denylist-file", cfg.DenylistFile,
+		"File of blocked substrings, one per line")
+	rootCmd.Flags().IntVar(&cfg.Best, "best", cfg.Best,
+		"Keep the top N addresses by score instead of stopping at an exact match")
+	rootCmd.Flags().StringVar(&cfg.Score, "score", cfg.Score,
+		`Score used by --best (one of: pattern, leading, run, zero-bytes, zero-nibbles)
+- pattern:      Leading characters of the pattern found at the position
+- leading:      Repeated characters at the start of the address
+- run:          Longest run of a repeated character
+- zero-bytes:   Leading zero bytes of the raw address
+- zero-nibbles: Leading zero nibbles of the raw address`)
+	rootCmd.Flags().DurationVar(&cfg.Duration, "duration", cfg.Duration,
+		"Time budget for --best, e.g. 30m or 1h")
+	rootCmd.Flags().IntVar(&cfg.Target, "target", cfg.Target,
+		"Stop --best as soon as an address reaches this score")
+	rootCmd.Flags().StringVar(&cfg.Dictionary, "dictionary", cfg.Dictionary,
+		"Wordlist file; match any address containing one of its words")
+	rootCmd.Flags().IntVar(&cfg.MinWordLength, "min-word-length", cfg.MinWordLength,
+		"Minimum length of dictionary words to match")
+
+	// Address Mode Options
+	rootCmd.Flags().StringVar(&cfg.Mode, "mode", cfg.Mode,
+		`Kind of address to search for (one of: account, object, create2, create, valoper, valcons, node, multisig, xpub)
+- account: Account keys (default)
+- object:  Seeds for a Move named object created by --creator
+- create2: Salts for an EVM contract deployed with CREATE2 by --deployer
+- create:  Deployer keys for an EVM contract deployed with CREATE
+- valoper: Validator operator keys, matched on their initvaloper1 address
+- valcons: Validator consensus keys (ed25519), matched on their initvalcons1 address
+- node:    CometBFT node keys (ed25519), matched on their hex node ID
+- multisig: The last member key of a --threshold multisig of --member keys
+- xpub:    Child indexes of an extended public key, watch-only`)
+	rootCmd.Flags().StringVar(&cfg.Creator, "creator", cfg.Creator,
+		"Creator address (init1... or 0x...) for object mode")
+	rootCmd.Flags().StringVar(&cfg.Deployer, "deployer", cfg.Deployer,
+		"Deployer address (0x... or init1...) for create2 mode")
+	rootCmd.Flags().StringVar(&cfg.InitCodeHash, "init-code-hash", cfg.InitCodeHash,
+		"Keccak-256 hash of the contract init code for create2 mode")
+	rootCmd.Flags().Uint64Var(&cfg.MaxNonce, "max-nonce", cfg.MaxNonce,
+		"Highest deployer nonce to check in create mode")
+	rootCmd.Flags().StringVar(&cfg.Encoding, "encoding", cfg.Encoding,
+		"Address encoding to match in object, create2 and create modes (one of: bech32, hex)")
+	rootCmd.Flags().BoolVar(&cfg.MatchAccount, "match-account", cfg.MatchAccount,
+		"In valoper mode, require the key's init1 account address to match as well")
+	rootCmd.Flags().StringArrayVar(&cfg.Members, "member", cfg.Members,
+		"Public key of a fixed multisig member, as JSON or base64 (repeatable, in order)")
+	rootCmd.Flags().IntVar(&cfg.Threshold, "threshold", cfg.Threshold,
+		"Number of signatures the multisig requires")
+	rootCmd.Flags().StringVar(&cfg.XPub, "xpub", cfg.XPub,
+		"Extended public key at m/44'/118'/0'/0 for xpub mode")
+	rootCmd.Flags().StringVar(&cfg.PubKey, "pubkey", cfg.PubKey,
+		"Public key at m/44'/118'/0'/0, as JSON or base64, for xpub mode without --xpub")
+	rootCmd.Flags().StringVar(&cfg.ChainCode, "chain-code", cfg.ChainCode,
+		"Hex chain code that goes with --pubkey")
+	rootCmd.Flags().StringVar(&cfg.KeyDir, "key-dir", cfg.KeyDir,
+		"Directory to write key files such as priv_validator_key.json and node_key.json to, one subdirectory per address")
 
 	// Key Generation Options
 	rootCmd.Flags().BoolVar(&cfg.UseMnemonic, "use-mnemonic", cfg.UseMnemonic,
 		"Use mnemonic-based key generation instead of random")
 	rootCmd.Flags().StringVar(&cfg.Mnemonic, "mnemonic", cfg.Mnemonic,
 		"Specify mnemonic phrase (optional, will be generated if not provided)")
+	rootCmd.Flags().IntVar(&cfg.Words, "words", cfg.Words,
+		"Number of mnemonic words (one of: 12, 15, 18, 21, 24; default: 24); a provided mnemonic must match")
+	rootCmd.Flags().StringVar(&cfg.Language, "language", cfg.Language,
+		"BIP39 wordlist of generated and provided mnemonics (one of: "+strings.Join(vanity.Languages(), ", ")+"; default: english)")
+	rootCmd.Flags().StringVar(&cfg.EntropyDice, "entropy-dice", cfg.EntropyDice,
+		"Dice rolls (digits 1-6) hashed together with the system RNG to seed every key")
+	rootCmd.Flags().StringVar(&cfg.EntropyHex, "entropy-hex", cfg.EntropyHex,
+		"Hex string hashed together with the system RNG to seed every key")
+	rootCmd.Flags().StringVar(&cfg.EntropyFile, "entropy-file", cfg.EntropyFile,
+		"File whose contents are hashed together with the system RNG to seed every key")
+	rootCmd.Flags().BoolVar(&cfg.EntropyAudit, "entropy-audit", cfg.EntropyAudit,
+		"Derive the mnemonic from --entropy-dice alone, without the system RNG, so it can be reproduced by hand")
 	rootCmd.Flags().Uint32Var(&cfg.AccountNumber, "account", cfg.AccountNumber,
 		"Account number for HD derivation path (default: 0)")
 	rootCmd.Flags().Uint32Var(&cfg.AddressIndex, "address-index", cfg.AddressIndex,
@@ -92,13 +262,35 @@ All generated addresses will start with 'init1'.`,
 }
 
 func run(cmd *cobra.Command, args []string) error {
+	// An audit derives its mnemonic from the dice alone and searches nothing
+	if cfg.EntropyAudit {
+		return audit(args)
+	}
+
 	// If no pattern is provided, display help
-	if len(args) == 0 {
+	if len(args) == 0 && cfg.PatternsFile == "" && cfg.Dictionary == "" && !cfg.HasConstraints() &&
+		(cfg.Best == 0 || cfg.Score == vanity.ScorePattern) {
 		return cmd.Help()
 	}
 
-	// Set pattern from args
-	cfg.Pattern = args[0]
+	// Collect patterns from args and the patterns file
+	for _, arg := range args {
+		p, err := config.ParsePattern(arg, cfg.Position, cfg.Count)
+		if err != nil {
+			return fmt.Errorf("invalid configuration: %v", err)
+		}
+		cfg.Patterns = append(cfg.Patterns, p)
+	}
+	if cfg.PatternsFile != "" {
+		patterns, err := config.LoadPatternsFile(cfg.PatternsFile, cfg.Position, cfg.Count)
+		if err != nil {
+			return fmt.Errorf("invalid configuration: %v", err)
+		}
+		cfg.Patterns = append(cfg.Patterns, patterns...)
+	}
+	if len(cfg.Patterns) == 1 {
+		cfg.Pattern = cfg.Patterns[0].Value
+	}
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
@@ -108,9 +300,77 @@ func run(cmd *cobra.Command, args []string) error {
 	// Create formatter
 	formatter := output.NewFormatter(cfg.Format, cfg.Quiet)
 
+	// Create generator
+	generator, err := newGenerator()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %v", err)
+	}
+
+	// Select the kind of address
+	if err := applyMode(generator); err != nil {
+		return fmt.Errorf("invalid configuration: %v", err)
+	}
+
+	// Set the mnemonic length
+	if cfg.Words != 0 {
+		if err := generator.SetWords(cfg.Words); err != nil {
+			return fmt.Errorf("invalid configuration: %v", err)
+		}
+	}
+
+	// Set the mnemonic language
+	if cfg.Language != "" {
+		if err := generator.SetLanguage(cfg.Language); err != nil {
+			return fmt.Errorf("invalid configuration: %v", err)
+		}
+	}
+
+	// Mix in user-supplied entropy
+	entropy, err := cfg.UserEntropy()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %v", err)
+	}
+	if entropy != nil {
+		generator.SetUserEntropy(entropy)
+		if !cfg.Quiet {
+			fmt.Printf("Mixing %d bytes of user-supplied entropy into every key\n", len(entropy))
+		}
+	}
+
+	// Allow fuzzy matches
+	if cfg.MaxDistance > 0 {
+		generator.SetMaxDistance(cfg.MaxDistance, cfg.Metric)
+		if !cfg.Quiet {
+			fmt.Printf("Accepting matches within %s distance %d\n", cfg.Metric, cfg.MaxDistance)
+		}
+	}
+
+	// Apply exclusions
+	excludes := cfg.Excludes
+	if cfg.DenylistFile != "" {
+		denylist, err := config.LoadWordlist(cfg.DenylistFile)
+		if err != nil {
+			return fmt.Errorf("invalid configuration: %v", err)
+		}
+		excludes = append(excludes, denylist...)
+	}
+	if len(excludes) > 0 {
+		generator.SetExclusions(excludes)
+		if !cfg.Quiet {
+			fmt.Printf("Excluding addresses containing any of %d blocked substrings\n", len(excludes))
+		}
+	}
+
+	scoresPattern := cfg.Best == 0 || cfg.Score == vanity.ScorePattern
+	difficulty := generator.Difficulty()
+	if scoresPattern && math.IsInf(difficulty, 1) {
+		return fmt.Errorf("invalid configuration: pattern can never match; addresses only use the characters %s", generator.Alphabet())
+	}
+
 	if !cfg.Quiet {
-		fmt.Printf("Searching for pattern: %s\n", cfg.Pattern)
-		fmt.Printf("Position: %s\n", cfg.Position)
+		if scoresPattern {
+			fmt.Printf("Difficulty: 1 in %.0f\n", difficulty)
+		}
 		fmt.Printf("Using %d threads\n", cfg.Threads)
 		if cfg.UseMnemonic {
 			fmt.Println("Using mnemonic-based generation")
@@ -120,12 +380,14 @@ func run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Create and start generator
-	startTime := time.Now()
-	generator := vanity.NewGenerator(cfg.Pattern, cfg.Position, cfg.CaseSensitive, cfg.Count, cfg.UseMnemonic, cfg.Mnemonic)
-
 	// Start generation
+	startTime := time.Now()
+	if cfg.Best > 0 && cfg.Duration > 0 {
+		time.AfterFunc(cfg.Duration, generator.Stop)
+	}
 	if err := generator.Generate(cfg.Threads); err != nil {
+		// Start on a fresh line after the progress output
+		fmt.Println()
 		return fmt.Errorf("generation failed: %v", err)
 	}
 
@@ -148,6 +410,11 @@ func run(cmd *cobra.Command, args []string) error {
 		fmt.Println(output)
 	}
 
+	// Write key files for the node software
+	if err := writeKeyFiles(results); err != nil {
+		return err
+	}
+
 	// Print statistics if requested
 	if cfg.Stats && !cfg.Quiet {
 		stats := generator.GetStats()
@@ -156,3 +423,198 @@ func run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// audit prints the account derived from the configured dice rolls alone
+func audit(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("invalid configuration: --entropy-audit takes no patterns")
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %v", err)
+	}
+
+	words := cfg.Words
+	if words == 0 {
+		words = vanity.DefaultWords
+	}
+	result, err := vanity.AuditResult(cfg.EntropyDice, words, cfg.AuditLanguage())
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %v", err)
+	}
+
+	formatter := output.NewFormatter(cfg.Format, cfg.Quiet)
+	output, err := formatter.FormatResults([]vanity.Result{result})
+	if err != nil {
+		return fmt.Errorf("error formatting results: %v", err)
+	}
+	if cfg.OutputFile != "" {
+		if err := os.WriteFile(cfg.OutputFile, []byte(output), 0600); err != nil {
+			return fmt.Errorf("error writing to file: %v", err)
+		}
+		if !cfg.Quiet {
+			fmt.Printf("Results written to %s\n", cfg.OutputFile)
+		}
+	} else {
+		fmt.Println(output)
+	}
+	return nil
+}
+
+// applyMode switches the generator to the configured kind of address
+func applyMode(generator *vanity.Generator) error {
+	switch cfg.Mode {
+	case config.ModeObject:
+		if err := generator.SetObjectCreator(cfg.Creator, cfg.Encoding); err != nil {
+			return err
+		}
+		if !cfg.Quiet {
+			fmt.Printf("Searching seeds for named objects created by %s (%s addresses)\n", cfg.Creator, cfg.Encoding)
+		}
+	case config.ModeCreate2:
+		if err := generator.SetCreate2(cfg.Deployer, cfg.InitCodeHash, cfg.Encoding); err != nil {
+			return err
+		}
+		if !cfg.Quiet {
+			fmt.Printf("Searching CREATE2 salts for contracts deployed by %s (%s addresses)\n", cfg.Deployer, cfg.Encoding)
+		}
+	case config.ModeCreate:
+		generator.SetCreate(cfg.MaxNonce, cfg.Encoding)
+		if !cfg.Quiet {
+			fmt.Printf("Searching deployer keys for contracts created at nonces 0 to %d (%s addresses)\n", cfg.MaxNonce, cfg.Encoding)
+		}
+	case config.ModeValoper:
+		generator.SetValoper(cfg.MatchAccount)
+		if !cfg.Quiet {
+			fmt.Println("Searching validator operator addresses (initvaloper1...)")
+		}
+	case config.ModeValcons:
+		generator.SetConsensus()
+		if !cfg.Quiet {
+			fmt.Println("Searching validator consensus keys (initvalcons1...)")
+		}
+	case config.ModeNode:
+		generator.SetNode()
+		if !cfg.Quiet {
+			fmt.Println("Searching node keys (hex node IDs)")
+		}
+	case config.ModeMultisig:
+		if err := generator.SetMultisig(cfg.Threshold, cfg.Members); err != nil {
+			return err
+		}
+		if !cfg.Quiet {
+			fmt.Printf("Searching %d-of-%d multisig addresses\n", cfg.Threshold, len(cfg.Members)+1)
+		}
+	case config.ModeXPub:
+		key, err := cfg.ExtendedPubKey()
+		if err != nil {
+			return err
+		}
+		if err := generator.SetExtendedPubKey(key); err != nil {
+			return err
+		}
+		if !cfg.Quiet {
+			fmt.Printf("Searching child indexes of %s, watch-only\n", vanity.XPubPath)
+		}
+	}
+	return nil
+}
+
+// writeKeyFiles writes the key files of the results to the key directory
+func writeKeyFiles(results []vanity.Result) error {
+	paths, err := output.WriteKeyFiles(cfg.KeyDir, results)
+	if !cfg.Quiet {
+		for _, path := range paths {
+			fmt.Printf("Key file written to %s\n", path)
+		}
+	}
+	return err
+}
+
+// newGenerator creates the generator for the configured search and prints
+// what is being searched for
+func newGenerator() (*vanity.Generator, error) {
+	if cfg.Dictionary != "" {
+		words, err := config.LoadWordlist(cfg.Dictionary)
+		if err != nil {
+			return nil, err
+		}
+		usable := vanity.FilterWords(words, cfg.MinWordLength)
+		if len(usable) == 0 {
+			return nil, fmt.Errorf("no words in %s of at least %d characters can be spelled in bech32", cfg.Dictionary, cfg.MinWordLength)
+		}
+		if !cfg.Quiet {
+			fmt.Printf("Searching for %d dictionary words of at least %d characters\n", len(usable), cfg.MinWordLength)
+		}
+		return vanity.NewDictionaryGenerator(usable, cfg.MinWordLength, cfg.Count, cfg.UseMnemonic, cfg.Mnemonic), nil
+	}
+
+	if cfg.Best > 0 {
+		var pattern vanity.Pattern
+		if patterns := cfg.SearchPatterns(); len(patterns) > 0 {
+			pattern = patterns[0]
+		}
+		if !cfg.Quiet {
+			fmt.Printf("Keeping the best %d addresses by %s score", cfg.Best, cfg.Score)
+			if cfg.Duration > 0 {
+				fmt.Printf(" for %v", cfg.Duration)
+			}
+			if cfg.Target > 0 {
+				fmt.Printf(", stopping at a score of %d", cfg.Target)
+			}
+			fmt.Println()
+			if pattern.Value != "" {
+				fmt.Printf("Pattern: %s (position: %s)\n", pattern.Value, pattern.Position)
+			}
+		}
+		generator := vanity.NewBestGenerator(pattern, cfg.Score, cfg.Best, cfg.CaseSensitive, cfg.UseMnemonic, cfg.Mnemonic)
+		if cfg.Target > 0 {
+			generator.SetTarget(cfg.Target)
+		}
+		return generator, nil
+	}
+
+	if cfg.HasConstraints() {
+		constraints := cfg.Constraints()
+		requireAll := cfg.Match == "all"
+		if !cfg.Quiet {
+			op := " OR "
+			if requireAll {
+				op = " AND "
+			}
+			parts := make([]string, len(constraints))
+			for i, c := range constraints {
+				parts[i] = c.String()
+			}
+			fmt.Printf("Searching for: %s\n", strings.Join(parts, op))
+		}
+		return vanity.NewConstraintGenerator(constraints, requireAll, cfg.CaseSensitive, cfg.Count, cfg.UseMnemonic, cfg.Mnemonic), nil
+	}
+
+	if cfg.Lookalikes {
+		table, err := cfg.LookalikeTable()
+		if err != nil {
+			return nil, err
+		}
+		generator, err := vanity.NewLookalikeGenerator(cfg.Patterns, table, cfg.UseMnemonic, cfg.Mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		if !cfg.Quiet {
+			fmt.Printf("Searching for lookalike variants of %d patterns\n", len(cfg.Patterns))
+		}
+		return generator, nil
+	}
+
+	if !cfg.Quiet {
+		if len(cfg.Patterns) == 1 {
+			fmt.Printf("Searching for pattern: %s\n", cfg.Pattern)
+			fmt.Printf("Position: %s\n", cfg.Patterns[0].Position)
+		} else {
+			fmt.Printf("Searching for %d patterns:\n", len(cfg.Patterns))
+			for _, p := range cfg.Patterns {
+				fmt.Printf("  %s (position: %s, count: %d)\n", p.Value, p.Position, p.Count)
+			}
+		}
+	}
+	return vanity.NewMultiGenerator(cfg.Patterns, cfg.CaseSensitive, cfg.UseMnemonic, cfg.Mnemonic), nil
+}
@@ -1,13 +1,76 @@
 package config
 
 import (
+	"bufio"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/degenhousedefi/initia-vanity/pkg/vanity"
+)
+
+// Address modes select what kind of address is searched for
+const (
+	// ModeAccount searches account keys, the default
+	ModeAccount = "account"
+	// ModeObject searches seeds for Move named-object addresses
+	ModeObject = "object"
+	// ModeCreate2 searches salts for EVM CREATE2 contract addresses
+	ModeCreate2 = "create2"
+	// ModeCreate searches deployer keys for EVM CREATE contract addresses
+	ModeCreate = "create"
+	// ModeValoper searches keys for validator operator addresses
+	ModeValoper = "valoper"
+	// ModeValcons searches ed25519 keys for validator consensus addresses
+	ModeValcons = "valcons"
+	// ModeNode searches ed25519 keys for CometBFT node IDs
+	ModeNode = "node"
+	// ModeMultisig searches the last member key of a multisig for the
+	// multisig address
+	ModeMultisig = "multisig"
+	// ModeXPub searches the children of an extended public key, watch-only
+	ModeXPub = "xpub"
 )
 
 // Config holds the generator configuration
+// An empty Mode means ModeAccount and an empty Encoding means bech32.
 type Config struct {
+	Mode          string
+	Creator       string
+	Deployer      string
+	InitCodeHash  string
+	MaxNonce      uint64
+	Encoding      string
+	MatchAccount  bool
+	KeyDir        string
+	Members       []string
+	Threshold     int
+	XPub          string
+	PubKey        string
+	ChainCode     string
 	Pattern       string
+	Patterns      []vanity.Pattern
+	PatternsFile  string
+	Dictionary    string
+	MinWordLength int
+	Prefixes      []string
+	Suffixes      []string
+	Contains      []string
+	Match         string
+	MaxDistance   int
+	Metric        string
+	Lookalikes    bool
+	LookalikeMap  []string
+	Excludes      []string
+	DenylistFile  string
+	Best          int
+	Score         string
+	Duration      time.Duration
+	Target        int
 	Position      string
 	Threads       int
 	CaseSensitive bool
@@ -18,25 +81,267 @@ type Config struct {
 	Stats         bool
 	UseMnemonic   bool
 	Mnemonic      string
+	Words         int
+	Language      string
+	EntropyDice   string
+	EntropyHex    string
+	EntropyFile   string
+	EntropyAudit  bool
 	AccountNumber uint32
 	AddressIndex  uint32
 }
 
+var validModes = map[string]bool{
+	"":           true,
+	ModeAccount:  true,
+	ModeObject:   true,
+	ModeCreate2:  true,
+	ModeCreate:   true,
+	ModeValoper:  true,
+	ModeValcons:  true,
+	ModeNode:     true,
+	ModeMultisig: true,
+	ModeXPub:     true,
+}
+
+var validPositions = map[string]bool{
+	"start": true,
+	"end":   true,
+	"any":   true,
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	// Validate position
-	validPositions := map[string]bool{
-		"start": true,
-		"end":   true,
-		"any":   true,
-	}
 	if !validPositions[c.Position] {
 		return fmt.Errorf("invalid position '%s': must be one of: start, end, any", c.Position)
 	}
 
-	// Validate pattern length
-	if len(c.Pattern) == 0 {
-		return fmt.Errorf("pattern cannot be empty")
+	// Validate address mode
+	if !validModes[c.Mode] {
+		return fmt.Errorf("invalid mode '%s': must be one of: account, object, create2, create, valoper, valcons, node, multisig, xpub", c.Mode)
+	}
+	if c.Encoding != "" && c.Encoding != vanity.EncodingBech32 && c.Encoding != vanity.EncodingHex {
+		return fmt.Errorf("invalid encoding '%s': must be one of: bech32, hex", c.Encoding)
+	}
+	contract := c.Mode == ModeObject || c.Mode == ModeCreate2 || c.Mode == ModeCreate
+	if !contract && c.Encoding == vanity.EncodingHex {
+		return fmt.Errorf("hex encoding is only available in object, create2 and create modes")
+	}
+	// These modes generate keys, but not from a mnemonic
+	mnemonicless := c.Mode == ModeValcons || c.Mode == ModeNode || c.Mode == ModeMultisig || c.Mode == ModeXPub
+	if (contract || mnemonicless) && (c.UseMnemonic || c.Mnemonic != "") {
+		return fmt.Errorf("%s mode cannot use a mnemonic", c.Mode)
+	}
+	if c.Mode != ModeCreate && c.MaxNonce > 0 {
+		return fmt.Errorf("--max-nonce is only used in create mode")
+	}
+	if c.Mode == ModeObject {
+		if c.Creator == "" {
+			return fmt.Errorf("object mode requires a --creator address")
+		}
+		if _, err := vanity.ParseMoveAddress(c.Creator); err != nil {
+			return fmt.Errorf("invalid creator: %v", err)
+		}
+	} else if c.Creator != "" {
+		return fmt.Errorf("--creator is only used in object mode")
+	}
+	if c.Mode == ModeCreate2 {
+		if c.Deployer == "" || c.InitCodeHash == "" {
+			return fmt.Errorf("create2 mode requires --deployer and --init-code-hash")
+		}
+		if _, err := vanity.ParseEVMAddress(c.Deployer); err != nil {
+			return fmt.Errorf("invalid deployer: %v", err)
+		}
+		if _, err := vanity.ParseHash(c.InitCodeHash); err != nil {
+			return fmt.Errorf("invalid init code hash: %v", err)
+		}
+	} else if c.Deployer != "" || c.InitCodeHash != "" {
+		return fmt.Errorf("--deployer and --init-code-hash are only used in create2 mode")
+	}
+
+	if c.Mode == ModeMultisig {
+		if len(c.Members) == 0 {
+			return fmt.Errorf("multisig mode requires at least one --member public key")
+		}
+		if c.Threshold < 1 || c.Threshold > len(c.Members)+1 {
+			return fmt.Errorf("--threshold must be between 1 and %d, the number of members including the generated key", len(c.Members)+1)
+		}
+		for _, member := range c.Members {
+			if _, err := vanity.ParsePubKey(member); err != nil {
+				return fmt.Errorf("invalid member: %v", err)
+			}
+		}
+	} else if len(c.Members) > 0 || c.Threshold != 0 {
+		return fmt.Errorf("--member and --threshold are only used in multisig mode")
+	}
+	if c.Mode == ModeXPub {
+		if _, err := c.ExtendedPubKey(); err != nil {
+			return err
+		}
+	} else if c.XPub != "" || c.PubKey != "" || c.ChainCode != "" {
+		return fmt.Errorf("--xpub, --pubkey and --chain-code are only used in xpub mode")
+	}
+	if c.MatchAccount {
+		if c.Mode != ModeValoper {
+			return fmt.Errorf("--match-account is only used in valoper mode")
+		}
+		// The operator and account forms share their data but not their checksum
+		for _, p := range c.SearchPatterns() {
+			if p.Position == "end" {
+				return fmt.Errorf("--match-account cannot be combined with end patterns: the two address forms have different checksums")
+			}
+		}
+	}
+
+	// Validate mnemonic length
+	if c.Words != 0 {
+		if _, err := vanity.EntropyBits(c.Words); err != nil {
+			return err
+		}
+		if !c.UseMnemonic && !c.EntropyAudit {
+			return fmt.Errorf("--words requires --use-mnemonic")
+		}
+		if n := len(strings.Fields(c.Mnemonic)); c.Mnemonic != "" && n != c.Words {
+			return fmt.Errorf("mnemonic has %d words, expected %d", n, c.Words)
+		}
+	}
+
+	// Validate mnemonic language
+	if c.Language != "" {
+		if err := vanity.ValidateLanguage(c.Language); err != nil {
+			return err
+		}
+		if !c.UseMnemonic && !c.EntropyAudit {
+			return fmt.Errorf("--language requires --use-mnemonic")
+		}
+	}
+
+	// Validate user entropy
+	sources := 0
+	for _, v := range []string{c.EntropyDice, c.EntropyHex, c.EntropyFile} {
+		if v != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("only one of --entropy-dice, --entropy-hex and --entropy-file can be used")
+	}
+	if sources > 0 {
+		// Object and create2 modes draw seeds and salts, and xpub mode draws
+		// nothing, so there is no key to protect
+		if c.Mode == ModeObject || c.Mode == ModeCreate2 || c.Mode == ModeXPub {
+			return fmt.Errorf("user entropy is only used in modes that generate keys, not %s mode", c.Mode)
+		}
+		if c.Mnemonic != "" {
+			return fmt.Errorf("user entropy cannot be combined with a provided mnemonic")
+		}
+		if _, err := c.UserEntropy(); err != nil {
+			return err
+		}
+	}
+	if c.EntropyAudit {
+		if c.EntropyDice == "" {
+			return fmt.Errorf("--entropy-audit requires --entropy-dice")
+		}
+		if len(c.Patterns) > 0 || c.Pattern != "" || c.Dictionary != "" || c.HasConstraints() {
+			return fmt.Errorf("--entropy-audit derives a single mnemonic and takes no patterns")
+		}
+		words := c.Words
+		if words == 0 {
+			words = vanity.DefaultWords
+		}
+		if _, err := vanity.AuditMnemonic(c.EntropyDice, words, c.AuditLanguage()); err != nil {
+			return err
+		}
+	}
+
+	// Validate dictionary mode
+	if c.Dictionary != "" && c.MinWordLength < 1 {
+		return fmt.Errorf("minimum word length must be at least 1")
+	}
+	// Wordlists are filtered to bech32, so hex addresses would miss words
+	if c.Dictionary != "" && (c.Mode == ModeNode || c.Encoding == vanity.EncodingHex) {
+		return fmt.Errorf("--dictionary only searches bech32 addresses, not node IDs or hex encoding")
+	}
+
+	// Validate constraints
+	if c.HasConstraints() {
+		if c.Dictionary != "" || len(c.Patterns) > 0 || c.Pattern != "" {
+			return fmt.Errorf("--prefix, --suffix and --contains cannot be combined with patterns or a dictionary")
+		}
+		if c.Match != "all" && c.Match != "any" {
+			return fmt.Errorf("invalid match mode '%s': must be one of: all, any", c.Match)
+		}
+	}
+
+	// Validate fuzzy matching
+	if c.MaxDistance < 0 {
+		return fmt.Errorf("max distance must not be negative")
+	}
+	if c.MaxDistance > 0 {
+		if c.Metric != vanity.MetricHamming && c.Metric != vanity.MetricLevenshtein {
+			return fmt.Errorf("invalid distance metric '%s': must be one of: hamming, levenshtein", c.Metric)
+		}
+		if c.Dictionary != "" || c.Best > 0 {
+			return fmt.Errorf("--max-distance cannot be combined with a dictionary or best-of mode")
+		}
+	}
+
+	// Validate lookalike expansion
+	if c.Lookalikes {
+		if c.Dictionary != "" || c.HasConstraints() || c.Best > 0 {
+			return fmt.Errorf("--lookalikes cannot be combined with a dictionary, constraints or best-of mode")
+		}
+		if _, err := c.LookalikeTable(); err != nil {
+			return err
+		}
+	}
+
+	// Validate best-of mode
+	if c.Best < 0 {
+		return fmt.Errorf("best must not be negative")
+	}
+	if c.Best > 0 {
+		validScores := map[string]bool{
+			vanity.ScorePattern:     true,
+			vanity.ScoreLeading:     true,
+			vanity.ScoreRun:         true,
+			vanity.ScoreZeroBytes:   true,
+			vanity.ScoreZeroNibbles: true,
+		}
+		if !validScores[c.Score] {
+			return fmt.Errorf("invalid score '%s': must be one of: pattern, leading, run, zero-bytes, zero-nibbles", c.Score)
+		}
+		if c.Duration < 0 || c.Target < 0 {
+			return fmt.Errorf("--duration and --target must not be negative")
+		}
+		if c.Duration == 0 && c.Target == 0 {
+			return fmt.Errorf("best-of mode requires a positive --duration or --target")
+		}
+		if c.Dictionary != "" || c.HasConstraints() || len(c.Patterns) > 1 {
+			return fmt.Errorf("best-of mode takes a single pattern")
+		}
+	}
+
+	// Validate patterns, of which an audit has none
+	patterns := c.SearchPatterns()
+	if c.EntropyAudit {
+		patterns = nil
+	}
+	for _, p := range patterns {
+		if len(p.Value) == 0 {
+			return fmt.Errorf("pattern cannot be empty")
+		}
+		if err := vanity.ValidatePattern(p.Value); err != nil {
+			return err
+		}
+		if !validPositions[p.Position] {
+			return fmt.Errorf("invalid position '%s' for pattern '%s': must be one of: start, end, any", p.Position, p.Value)
+		}
+		if p.Count < 1 {
+			return fmt.Errorf("count for pattern '%s' must be at least 1", p.Value)
+		}
 	}
 
 	// Validate threads
@@ -61,14 +366,230 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// SearchPatterns returns the patterns to search for. A config without an
+// explicit pattern list searches for Pattern at Position. Dictionary mode has
+// no patterns.
+func (c *Config) SearchPatterns() []vanity.Pattern {
+	if c.Dictionary != "" {
+		return nil
+	}
+	if c.HasConstraints() {
+		return c.Constraints()
+	}
+	if len(c.Patterns) > 0 {
+		return c.Patterns
+	}
+	if c.Best > 0 && c.Score != vanity.ScorePattern && c.Pattern == "" {
+		return nil
+	}
+	return []vanity.Pattern{{Value: c.Pattern, Position: c.Position, Count: c.Count}}
+}
+
+// HasConstraints reports whether any prefix, suffix or contains constraint
+// is set
+func (c *Config) HasConstraints() bool {
+	return len(c.Prefixes)+len(c.Suffixes)+len(c.Contains) > 0
+}
+
+// Constraints returns the prefix, suffix and contains constraints as patterns
+func (c *Config) Constraints() []vanity.Pattern {
+	var constraints []vanity.Pattern
+	add := func(values []string, position string) {
+		for _, v := range values {
+			constraints = append(constraints, vanity.Pattern{Value: v, Position: position, Count: c.Count})
+		}
+	}
+	add(c.Prefixes, "start")
+	add(c.Suffixes, "end")
+	add(c.Contains, "any")
+	return constraints
+}
+
+// ExtendedPubKey returns the extended public key of xpub mode, given either
+// as XPub or as PubKey and ChainCode
+func (c *Config) ExtendedPubKey() (*vanity.ExtendedPubKey, error) {
+	if (c.XPub == "") == (c.PubKey == "" && c.ChainCode == "") {
+		return nil, fmt.Errorf("xpub mode requires either --xpub or --pubkey with --chain-code")
+	}
+
+	if c.XPub != "" {
+		return vanity.ParseExtendedPubKey(c.XPub)
+	}
+	pubKey, err := vanity.ParsePubKey(c.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	chainCode, err := hex.DecodeString(strings.TrimPrefix(c.ChainCode, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid chain code: %v", err)
+	}
+	return vanity.NewExtendedPubKey(pubKey.Bytes(), chainCode)
+}
+
+// UserEntropy returns the extra entropy given as dice rolls, hex or a file,
+// or nil if there is none
+func (c *Config) UserEntropy() ([]byte, error) {
+	switch {
+	case c.EntropyDice != "":
+		digits, err := vanity.ParseDice(c.EntropyDice)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(digits), nil
+	case c.EntropyHex != "":
+		data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(c.EntropyHex), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid entropy hex: %v", err)
+		}
+		if len(data) == 0 {
+			return nil, fmt.Errorf("entropy hex is empty")
+		}
+		return data, nil
+	case c.EntropyFile != "":
+		data, err := os.ReadFile(c.EntropyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read entropy file: %v", err)
+		}
+		if len(data) == 0 {
+			return nil, fmt.Errorf("entropy file %s is empty", c.EntropyFile)
+		}
+		return data, nil
+	}
+	return nil, nil
+}
+
+// AuditLanguage returns the language of the mnemonic derived by
+// --entropy-audit
+func (c *Config) AuditLanguage() string {
+	if c.Language == "" {
+		return vanity.LanguageEnglish
+	}
+	return c.Language
+}
+
+// LookalikeTable returns the default lookalike table with the entries from
+// LookalikeMap applied. Each entry is written as char=substitutes, e.g. "i=l7".
+// A "-" among the substitutes, or no substitutes at all, lets the character
+// be dropped.
+func (c *Config) LookalikeTable() (map[byte]string, error) {
+	table := make(map[byte]string, len(vanity.DefaultLookalikes))
+	for k, v := range vanity.DefaultLookalikes {
+		table[k] = v
+	}
+	for _, entry := range c.LookalikeMap {
+		from, to, ok := strings.Cut(entry, "=")
+		if !ok || len(from) != 1 {
+			return nil, fmt.Errorf("invalid lookalike mapping '%s': expected char=substitutes", entry)
+		}
+		if to == "" {
+			to = string(vanity.DropMarker)
+		}
+		table[strings.ToLower(from)[0]] = strings.ToLower(to)
+	}
+	return table, nil
+}
+
+// ParsePattern parses a pattern spec of the form value[,position[,count]].
+// Omitted fields fall back to the given defaults.
+func ParsePattern(spec, defaultPosition string, defaultCount int) (vanity.Pattern, error) {
+	fields := strings.Split(spec, ",")
+	if len(fields) > 3 {
+		return vanity.Pattern{}, fmt.Errorf("invalid pattern '%s': expected value[,position[,count]]", spec)
+	}
+
+	p := vanity.Pattern{
+		Value:    strings.TrimSpace(fields[0]),
+		Position: defaultPosition,
+		Count:    defaultCount,
+	}
+	if len(fields) > 1 {
+		p.Position = strings.TrimSpace(fields[1])
+	}
+	if len(fields) > 2 {
+		count, err := strconv.Atoi(strings.TrimSpace(fields[2]))
+		if err != nil {
+			return vanity.Pattern{}, fmt.Errorf("invalid count in pattern '%s': %v", spec, err)
+		}
+		p.Count = count
+	}
+
+	return p, nil
+}
+
+// LoadPatternsFile reads pattern specs from a file, one per line. Blank lines
+// and lines starting with '#' are ignored.
+func LoadPatternsFile(path, defaultPosition string, defaultCount int) ([]vanity.Pattern, error) {
+	var patterns []vanity.Pattern
+	err := readLines(path, func(line int, text string) error {
+		p, err := ParsePattern(text, defaultPosition, defaultCount)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+		patterns = append(patterns, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
+
+// LoadWordlist reads a wordlist with one word per line. Blank lines and lines
+// starting with '#' are ignored.
+func LoadWordlist(path string) ([]string, error) {
+	var words []string
+	err := readLines(path, func(_ int, text string) error {
+		words = append(words, text)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return words, nil
+}
+
+// readLines calls fn with every non-blank, non-comment line of a file
+func readLines(path string, fn func(line int, text string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := fn(line, text); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	return nil
+}
+
 // DefaultConfig returns a configuration with default values
 func DefaultConfig() *Config {
 	return &Config{
+		Mode:          ModeAccount,
+		Encoding:      vanity.EncodingBech32,
 		Position:      "end",
 		Threads:       runtime.NumCPU(),
 		CaseSensitive: false,
 		Format:        "text",
 		Count:         1,
+		MinWordLength: 4,
+		Match:         "all",
+		Score:         vanity.ScorePattern,
+		Metric:        vanity.MetricHamming,
+		KeyDir:        ".",
 		AccountNumber: 0,
 		AddressIndex:  0,
 	}
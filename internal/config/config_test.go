@@ -1,8 +1,16 @@
 package config
 
 import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/degenhousedefi/initia-vanity/pkg/vanity"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -23,6 +31,33 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.Count != 1 {
 		t.Errorf("expected default count 1, got %d", cfg.Count)
 	}
+	if cfg.Match != "all" {
+		t.Errorf("expected default match mode 'all', got '%s'", cfg.Match)
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	cfg := &Config{
+		Prefixes: []string{"dew"},
+		Suffixes: []string{"dew"},
+		Contains: []string{"xyz"},
+		Count:    2,
+	}
+
+	want := []vanity.Pattern{
+		{Value: "dew", Position: "start", Count: 2},
+		{Value: "dew", Position: "end", Count: 2},
+		{Value: "xyz", Position: "any", Count: 2},
+	}
+	got := cfg.Constraints()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d constraints, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("constraint %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
 }
 
 func TestValidate(t *testing.T) {
@@ -87,23 +122,1098 @@ func TestValidate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "invalid format",
+			name: "valid pattern list",
 			config: &Config{
-				Pattern:  "test",
+				Patterns: []vanity.Pattern{
+					{Value: "alice", Position: "start", Count: 1},
+					{Value: "bob", Position: "end", Count: 3},
+				},
 				Position: "end",
 				Threads:  1,
-				Format:   "invalid",
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid position in pattern list",
+			config: &Config{
+				Patterns: []vanity.Pattern{
+					{Value: "alice", Position: "middle", Count: 1},
+				},
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
 				Count:    1,
 			},
 			wantErr: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := tt.config.Validate()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+		{
+			name: "invalid count in pattern list",
+			config: &Config{
+				Patterns: []vanity.Pattern{
+					{Value: "alice", Position: "end", Count: 0},
+				},
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid wildcard pattern",
+			config: &Config{
+				Pattern:  "[02468]{5}",
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "malformed wildcard pattern",
+			config: &Config{
+				Pattern:  "dao[0-9",
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid pattern class",
+			config: &Config{
+				Pattern:  "repeat:6",
+				Position: "any",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown pattern class",
+			config: &Config{
+				Pattern:  "shiny:6",
+				Position: "any",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid lookalike mapping",
+			config: &Config{
+				Pattern:      "alice",
+				Lookalikes:   true,
+				LookalikeMap: []string{"i=ly"},
+				Position:     "end",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid lookalike mapping",
+			config: &Config{
+				Pattern:      "alice",
+				Lookalikes:   true,
+				LookalikeMap: []string{"il"},
+				Position:     "end",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid object mode",
+			config: &Config{
+				Mode:     ModeObject,
+				Creator:  "init1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc535vdd7",
+				Encoding: "hex",
+				Pattern:  "cafe",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "object mode without creator",
+			config: &Config{
+				Mode:     ModeObject,
+				Encoding: "bech32",
+				Pattern:  "dew",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "object mode with invalid creator",
+			config: &Config{
+				Mode:     ModeObject,
+				Creator:  "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu",
+				Encoding: "bech32",
+				Pattern:  "dew",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "object mode with mnemonic",
+			config: &Config{
+				Mode:        ModeObject,
+				Creator:     "0x1",
+				Encoding:    "bech32",
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+				UseMnemonic: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid create2 mode",
+			config: &Config{
+				Mode:         ModeCreate2,
+				Deployer:     "0x00000000000000000000000000000000deadbeef",
+				InitCodeHash: "0xbc36789e7a1e281436464229828f817d6612f7b477d66591ff96a9e064bcc98a",
+				Encoding:     "hex",
+				Pattern:      "0000",
+				Position:     "start",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "create2 mode with short init code hash",
+			config: &Config{
+				Mode:         ModeCreate2,
+				Deployer:     "0x00000000000000000000000000000000deadbeef",
+				InitCodeHash: "0xbc36789e",
+				Encoding:     "hex",
+				Pattern:      "0000",
+				Position:     "start",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "deployer outside create2 mode",
+			config: &Config{
+				Mode:     ModeObject,
+				Creator:  "0x1",
+				Deployer: "0x00000000000000000000000000000000deadbeef",
+				Pattern:  "dew",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid create mode",
+			config: &Config{
+				Mode:     ModeCreate,
+				MaxNonce: 5,
+				Encoding: "hex",
+				Pattern:  "cafe",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "max nonce outside create mode",
+			config: &Config{
+				Mode:     ModeAccount,
+				MaxNonce: 5,
+				Pattern:  "dew",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "hex encoding for accounts",
+			config: &Config{
+				Mode:     ModeAccount,
+				Encoding: "hex",
+				Pattern:  "cafe",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valoper matching the account form",
+			config: &Config{
+				Mode:         ModeValoper,
+				MatchAccount: true,
+				UseMnemonic:  true,
+				Pattern:      "dew",
+				Position:     "start",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valoper matching the account form at the end",
+			config: &Config{
+				Mode:         ModeValoper,
+				MatchAccount: true,
+				Pattern:      "dew",
+				Position:     "end",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "match account outside valoper mode",
+			config: &Config{
+				Mode:         ModeAccount,
+				MatchAccount: true,
+				Pattern:      "dew",
+				Position:     "start",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid valcons mode",
+			config: &Config{
+				Mode:     ModeValcons,
+				Pattern:  "dew",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valcons mode with mnemonic",
+			config: &Config{
+				Mode:        ModeValcons,
+				UseMnemonic: true,
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "node mode with mnemonic",
+			config: &Config{
+				Mode:     ModeNode,
+				Mnemonic: "abandon",
+				Pattern:  "cafe",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid multisig mode",
+			config: &Config{
+				Mode:      ModeMultisig,
+				Members:   []string{"A8Y9QlXr8bJ/qM4EFYs5U7AFrZDYOZgYtUomLwEVVGl2"},
+				Threshold: 2,
+				Pattern:   "dao",
+				Position:  "start",
+				Threads:   1,
+				Format:    "text",
+				Count:     1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "multisig threshold above members",
+			config: &Config{
+				Mode:      ModeMultisig,
+				Members:   []string{"A8Y9QlXr8bJ/qM4EFYs5U7AFrZDYOZgYtUomLwEVVGl2"},
+				Threshold: 3,
+				Pattern:   "dao",
+				Position:  "start",
+				Threads:   1,
+				Format:    "text",
+				Count:     1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "multisig with invalid member",
+			config: &Config{
+				Mode:      ModeMultisig,
+				Members:   []string{"cafe"},
+				Threshold: 1,
+				Pattern:   "dao",
+				Position:  "start",
+				Threads:   1,
+				Format:    "text",
+				Count:     1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "members outside multisig mode",
+			config: &Config{
+				Mode:     ModeAccount,
+				Members:  []string{"A8Y9QlXr8bJ/qM4EFYs5U7AFrZDYOZgYtUomLwEVVGl2"},
+				Pattern:  "dao",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "xpub mode with mnemonic",
+			config: &Config{
+				Mode:        ModeXPub,
+				XPub:        "xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV",
+				UseMnemonic: true,
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "xpub outside xpub mode",
+			config: &Config{
+				Mode:     ModeAccount,
+				XPub:     "xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV",
+				Pattern:  "dew",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "12-word mnemonics",
+			config: &Config{
+				UseMnemonic: true,
+				Words:       12,
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid mnemonic length",
+			config: &Config{
+				UseMnemonic: true,
+				Words:       13,
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "supplied mnemonic of another length",
+			config: &Config{
+				UseMnemonic: true,
+				Mnemonic:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				Words:       24,
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "words without mnemonic",
+			config: &Config{
+				Words:    12,
+				Pattern:  "dew",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "japanese mnemonics",
+			config: &Config{
+				UseMnemonic: true,
+				Language:    vanity.LanguageJapanese,
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported mnemonic language",
+			config: &Config{
+				UseMnemonic: true,
+				Language:    "klingon",
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "language without mnemonic",
+			config: &Config{
+				Language: vanity.LanguageSpanish,
+				Pattern:  "dew",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "dice entropy",
+			config: &Config{
+				UseMnemonic: true,
+				EntropyDice: "3164 2552 65",
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid dice roll",
+			config: &Config{
+				EntropyDice: "31647",
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "two entropy sources",
+			config: &Config{
+				EntropyDice: "316",
+				EntropyHex:  "deadbeef",
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "entropy for node keys",
+			config: &Config{
+				Mode:       ModeNode,
+				EntropyHex: "deadbeef",
+				Pattern:    "cafe",
+				Position:   "start",
+				Threads:    1,
+				Format:     "text",
+				Count:      1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "entropy for multisig member keys",
+			config: &Config{
+				Mode:        ModeMultisig,
+				Members:     []string{"A8Y9QlXr8bJ/qM4EFYs5U7AFrZDYOZgYtUomLwEVVGl2"},
+				Threshold:   2,
+				EntropyDice: "316425",
+				Pattern:     "dao",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "entropy without keys",
+			config: &Config{
+				Mode:       ModeObject,
+				Creator:    "0x1",
+				EntropyHex: "deadbeef",
+				Pattern:    "cafe",
+				Position:   "start",
+				Threads:    1,
+				Format:     "text",
+				Count:      1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "entropy with provided mnemonic",
+			config: &Config{
+				UseMnemonic: true,
+				Mnemonic:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				EntropyHex:  "deadbeef",
+				Pattern:     "dew",
+				Position:    "start",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "dice audit",
+			config: &Config{
+				EntropyDice:  strings.Repeat("6", 100),
+				EntropyAudit: true,
+				Words:        24,
+				Language:     vanity.LanguageJapanese,
+				Position:     "end",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "audit with too few rolls",
+			config: &Config{
+				EntropyDice:  "316425",
+				EntropyAudit: true,
+				Position:     "end",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "audit without dice",
+			config: &Config{
+				EntropyHex:   "deadbeef",
+				EntropyAudit: true,
+				Position:     "end",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "audit with a pattern",
+			config: &Config{
+				EntropyDice:  strings.Repeat("6", 100),
+				EntropyAudit: true,
+				Pattern:      "dew",
+				Position:     "end",
+				Threads:      1,
+				Format:       "text",
+				Count:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid mode",
+			config: &Config{
+				Mode:     "contract",
+				Pattern:  "dew",
+				Position: "start",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid fuzzy matching",
+			config: &Config{
+				Pattern:     "dewmud",
+				MaxDistance: 1,
+				Metric:      "levenshtein",
+				Position:    "end",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid distance metric",
+			config: &Config{
+				Pattern:     "dewmud",
+				MaxDistance: 1,
+				Metric:      "euclidean",
+				Position:    "end",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max distance",
+			config: &Config{
+				Pattern:     "dewmud",
+				MaxDistance: -1,
+				Metric:      "hamming",
+				Position:    "end",
+				Threads:     1,
+				Format:      "text",
+				Count:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid dictionary mode",
+			config: &Config{
+				Dictionary:    "words.txt",
+				MinWordLength: 4,
+				Position:      "end",
+				Threads:       1,
+				Format:        "text",
+				Count:         1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "dictionary with node IDs",
+			config: &Config{
+				Mode:          ModeNode,
+				Dictionary:    "words.txt",
+				MinWordLength: 4,
+				Position:      "end",
+				Threads:       1,
+				Format:        "text",
+				Count:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "dictionary with hex encoding",
+			config: &Config{
+				Mode:          ModeCreate,
+				Encoding:      vanity.EncodingHex,
+				Dictionary:    "words.txt",
+				MinWordLength: 4,
+				Position:      "end",
+				Threads:       1,
+				Format:        "text",
+				Count:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid minimum word length",
+			config: &Config{
+				Dictionary:    "words.txt",
+				MinWordLength: 0,
+				Position:      "end",
+				Threads:       1,
+				Format:        "text",
+				Count:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid constraints",
+			config: &Config{
+				Prefixes: []string{"dew"},
+				Suffixes: []string{"dew"},
+				Match:    "all",
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "constraints with pattern",
+			config: &Config{
+				Pattern:  "dew",
+				Contains: []string{"xyz"},
+				Match:    "all",
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid match mode",
+			config: &Config{
+				Prefixes: []string{"dew"},
+				Match:    "some",
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty constraint",
+			config: &Config{
+				Suffixes: []string{""},
+				Match:    "any",
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid best-of pattern mode",
+			config: &Config{
+				Pattern:  "dewmud",
+				Best:     10,
+				Score:    "pattern",
+				Duration: time.Hour,
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "best-of run mode without pattern",
+			config: &Config{
+				Best:     10,
+				Score:    "run",
+				Duration: time.Hour,
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "best-of pattern mode without pattern",
+			config: &Config{
+				Best:     10,
+				Score:    "pattern",
+				Duration: time.Hour,
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "best-of mode without duration",
+			config: &Config{
+				Pattern:  "dewmud",
+				Best:     10,
+				Score:    "pattern",
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "best-of zero bytes with target",
+			config: &Config{
+				Mode:     ModeCreate,
+				Encoding: "hex",
+				Best:     5,
+				Score:    "zero-bytes",
+				Target:   3,
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative target",
+			config: &Config{
+				Best:     5,
+				Score:    "zero-nibbles",
+				Duration: time.Hour,
+				Target:   -1,
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid score",
+			config: &Config{
+				Pattern:  "dewmud",
+				Best:     10,
+				Score:    "beauty",
+				Duration: time.Hour,
+				Position: "end",
+				Threads:  1,
+				Format:   "text",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid format",
+			config: &Config{
+				Pattern:  "test",
+				Position: "end",
+				Threads:  1,
+				Format:   "invalid",
+				Count:    1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    vanity.Pattern
+		wantErr bool
+	}{
+		{
+			name: "value only",
+			spec: "alice",
+			want: vanity.Pattern{Value: "alice", Position: "end", Count: 1},
+		},
+		{
+			name: "value and position",
+			spec: "alice,start",
+			want: vanity.Pattern{Value: "alice", Position: "start", Count: 1},
+		},
+		{
+			name: "value, position and count",
+			spec: "alice, any, 4",
+			want: vanity.Pattern{Value: "alice", Position: "any", Count: 4},
+		},
+		{
+			name:    "invalid count",
+			spec:    "alice,start,many",
+			wantErr: true,
+		},
+		{
+			name:    "too many fields",
+			spec:    "alice,start,1,2",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePattern(tt.spec, "end", 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParsePattern() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPatternsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.txt")
+	content := "# team roster\nalice,start\n\nbob,end,2\ncarol\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	patterns, err := LoadPatternsFile(path, "any", 1)
+	if err != nil {
+		t.Fatalf("LoadPatternsFile() error = %v", err)
+	}
+
+	want := []vanity.Pattern{
+		{Value: "alice", Position: "start", Count: 1},
+		{Value: "bob", Position: "end", Count: 2},
+		{Value: "carol", Position: "any", Count: 1},
+	}
+	if len(patterns) != len(want) {
+		t.Fatalf("expected %d patterns, got %d", len(want), len(patterns))
+	}
+	for i := range want {
+		if patterns[i] != want[i] {
+			t.Errorf("pattern %d = %+v, want %+v", i, patterns[i], want[i])
+		}
+	}
+}
+
+func TestLoadWordlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte("# words\ndance\n\n  zeta \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	words, err := LoadWordlist(path)
+	if err != nil {
+		t.Fatalf("LoadWordlist() error = %v", err)
+	}
+	if len(words) != 2 || words[0] != "dance" || words[1] != "zeta" {
+		t.Errorf("unexpected words: %v", words)
+	}
+}
+
+func TestUserEntropy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "entropy.bin")
+	if err := os.WriteFile(path, []byte{0xde, 0xad}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  Config
+		want    string
+		wantErr bool
+	}{
+		{"none", Config{}, "", false},
+		{"dice", Config{EntropyDice: "1 6\n2"}, "162", false},
+		{"hex", Config{EntropyHex: "0xdead"}, "\xde\xad", false},
+		{"file", Config{EntropyFile: path}, "\xde\xad", false},
+		{"invalid hex", Config{EntropyHex: "xyz"}, "", true},
+		{"missing file", Config{EntropyFile: path + ".missing"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.UserEntropy()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UserEntropy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("UserEntropy() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookalikeTable(t *testing.T) {
+	cfg := &Config{LookalikeMap: []string{"I=y", "o="}}
+	table, err := cfg.LookalikeTable()
+	if err != nil {
+		t.Fatalf("LookalikeTable() error = %v", err)
+	}
+	if table['i'] != "y" {
+		t.Errorf("expected override i=y, got %q", table['i'])
+	}
+	if table['o'] != "-" {
+		t.Errorf("expected o to be dropped, got %q", table['o'])
+	}
+
+	// alice -> alce with i dropped
+	cfg = &Config{LookalikeMap: []string{"i="}}
+	table, _ = cfg.LookalikeTable()
+	variants, err := vanity.ExpandLookalikes("alice", table)
+	if err != nil || !slices.Contains(variants, "alce") {
+		t.Errorf("ExpandLookalikes() = %v, %v, want alce among them", variants, err)
+	}
+	if table['b'] != vanity.DefaultLookalikes['b'] {
+		t.Errorf("expected default for b, got %q", table['b'])
+	}
+}
+
+func TestExtendedPubKey(t *testing.T) {
+	// BIP32 test vector 1 at m/0'/1/2'/2, a depth of 4
+	const (
+		xpub      = "xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV"
+		pubKey    = "AuhEUIKnLym3XKSHSKkU32BiKmCcrPzo7Q41gEVgdB0p"
+		chainCode = "cfb71883f01676f587d023cc53a35bc7f88f724b1f8c2892ac1275ac822a3edd"
+	)
+
+	tests := []struct {
+		name    string
+		config  *Config
+		wantErr bool
+	}{
+		{"xpub", &Config{XPub: xpub}, false},
+		{"public key and chain code", &Config{PubKey: pubKey, ChainCode: "0x" + chainCode}, false},
+		{"both forms", &Config{XPub: xpub, PubKey: pubKey, ChainCode: chainCode}, true},
+		{"neither form", &Config{}, true},
+		{"missing chain code", &Config{PubKey: pubKey}, true},
+		{"invalid chain code", &Config{PubKey: pubKey, ChainCode: "zz"}, true},
+		{"invalid xpub", &Config{XPub: xpub[:100]}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := tt.config.ExtendedPubKey()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExtendedPubKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && base64.StdEncoding.EncodeToString(key.Key) != pubKey {
+				t.Errorf("ExtendedPubKey() key = %x", key.Key)
 			}
 		})
 	}
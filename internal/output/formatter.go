@@ -39,14 +39,91 @@ func (f *Formatter) FormatResults(results []vanity.Result) (string, error) {
 
 	var builder strings.Builder
 	for _, result := range results {
-		// Always present fields
 		builder.WriteString(fmt.Sprintf("Address: %s\n", result.Address))
-		builder.WriteString(fmt.Sprintf("Private key: %s\n", result.PrivateKey))
-		builder.WriteString(fmt.Sprintf("Public key: %s\n", result.PublicKey))
+
+		// Key fields, absent for addresses that have no key
+		if result.PrivateKey != "" {
+			builder.WriteString(fmt.Sprintf("Private key: %s\n", result.PrivateKey))
+		}
+		if result.PublicKey != "" {
+			builder.WriteString(fmt.Sprintf("Public key: %s\n", result.PublicKey))
+		}
+
+		// Both forms of a keyless address
+		if result.HexAddress != "" && result.HexAddress != result.Address {
+			builder.WriteString(fmt.Sprintf("Hex address: %s\n", result.HexAddress))
+		}
+		if result.Bech32Address != "" && result.Bech32Address != result.Address {
+			builder.WriteString(fmt.Sprintf("Bech32 address: %s\n", result.Bech32Address))
+		}
+
+		// Account form of a validator operator address
+		if result.AccountAddress != "" && result.AccountAddress != result.Address {
+			builder.WriteString(fmt.Sprintf("Account address: %s\n", result.AccountAddress))
+		}
+
+		if result.MultisigPubKey != "" {
+			builder.WriteString(fmt.Sprintf("Multisig public key: %s\n", result.MultisigPubKey))
+			builder.WriteString("Note: The generated key is the last member; pass --nosort when recreating the multisig with keys add --multisig\n")
+		}
+		if result.Index != nil {
+			builder.WriteString(fmt.Sprintf("Index: %d\n", *result.Index))
+			builder.WriteString(fmt.Sprintf("Derivation path: %s\n", result.DerivationPath))
+			builder.WriteString("Note: Derive the private key for this path on the device holding the seed\n")
+		}
+		if result.KeyFileName != "" {
+			builder.WriteString(fmt.Sprintf("Key file: %s\n", result.KeyFileName))
+		}
+
+		// Named object fields
+		if result.Seed != "" {
+			builder.WriteString(fmt.Sprintf("Creator: %s\n", result.Creator))
+			builder.WriteString(fmt.Sprintf("Seed: %s\n", result.Seed))
+			builder.WriteString(fmt.Sprintf("Note: Create the object with object::create_named_object(creator, b\"%s\")\n", result.Seed))
+		}
+
+		// Contract deployment fields
+		if result.Deployer != "" {
+			builder.WriteString(fmt.Sprintf("Deployer: %s\n", result.Deployer))
+		}
+		if result.Salt != "" {
+			builder.WriteString(fmt.Sprintf("Salt: %s\n", result.Salt))
+		}
+		if result.Nonce != nil {
+			builder.WriteString(fmt.Sprintf("Nonce: %d\n", *result.Nonce))
+			builder.WriteString("Note: Deploy from this key when its account nonce reaches the value above\n")
+		}
+
+		if result.Pattern != "" {
+			builder.WriteString(fmt.Sprintf("Pattern: %s\n", result.Pattern))
+		}
+		if result.Variant != "" {
+			builder.WriteString(fmt.Sprintf("Variant: %s\n", result.Variant))
+		}
+		if result.Distance > 0 {
+			builder.WriteString(fmt.Sprintf("Distance: %d\n", result.Distance))
+		}
+		if result.Score > 0 {
+			builder.WriteString(fmt.Sprintf("Score: %d\n", result.Score))
+		}
+		if result.Word != "" {
+			builder.WriteString(fmt.Sprintf("Word: %s (offset %d)\n", result.Word, result.Offset))
+		}
+		if result.Attempt > 0 {
+			builder.WriteString(fmt.Sprintf("Found: attempt %d after %.2fs by worker %d",
+				result.Attempt, result.Elapsed, result.Worker))
+			if result.Luck > 0 {
+				builder.WriteString(fmt.Sprintf(" (luck %.2f)", result.Luck))
+			}
+			builder.WriteString("\n")
+		}
 
 		// Mnemonic-specific fields
 		if result.Mnemonic != "" {
 			builder.WriteString(fmt.Sprintf("Mnemonic: %s\n", result.Mnemonic))
+			if result.Language != "" {
+				builder.WriteString(fmt.Sprintf("Mnemonic language: %s\n", result.Language))
+			}
 			builder.WriteString(fmt.Sprintf("Derivation path: %s\n", result.DerivationPath))
 			builder.WriteString(fmt.Sprintf("Note: Import this mnemonic in your wallet to access this address\n"))
 		}
@@ -60,12 +137,38 @@ func (f *Formatter) FormatResults(results []vanity.Result) (string, error) {
 // FormatStats formats the generation statistics
 func (f *Formatter) FormatStats(stats vanity.Stats, duration time.Duration) string {
 	speed := float64(stats.Attempts) / duration.Seconds()
+
+	if f.format == "json" {
+		report := struct {
+			vanity.Stats
+			Duration float64 `json:"duration_seconds"`
+			Speed    float64 `json:"speed"`
+		}{stats, duration.Seconds(), speed}
+		if duration <= 0 {
+			report.Speed = 0
+		}
+		jsonData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Sprintf("error encoding JSON: %v\n", err)
+		}
+		return "\n" + string(jsonData) + "\n"
+	}
+
 	var builder strings.Builder
 
 	builder.WriteString("\nStatistics:\n")
+	if stats.StartTime != 0 {
+		builder.WriteString(fmt.Sprintf("Started: %s\n", time.Unix(0, stats.StartTime).Format(time.RFC3339)))
+	}
+	if stats.EndTime != 0 {
+		builder.WriteString(fmt.Sprintf("Finished: %s\n", time.Unix(0, stats.EndTime).Format(time.RFC3339)))
+	}
 	builder.WriteString(fmt.Sprintf("Duration: %v\n", duration.Round(time.Second)))
 	builder.WriteString(fmt.Sprintf("Total attempts: %d\n", stats.Attempts))
 	builder.WriteString(fmt.Sprintf("Addresses found: %d\n", stats.Found))
+	if stats.Rejected > 0 {
+		builder.WriteString(fmt.Sprintf("Rejected by exclusions: %d\n", stats.Rejected))
+	}
 	builder.WriteString(fmt.Sprintf("Average speed: %.2f addresses/second\n", speed))
 
 	if stats.Found > 0 {
@@ -73,6 +176,23 @@ func (f *Formatter) FormatStats(stats vanity.Stats, duration time.Duration) stri
 		builder.WriteString(fmt.Sprintf("Attempts per match: %.2f\n", attemptsPerMatch))
 	}
 
+	if stats.BestPartial > 0 {
+		builder.WriteString(fmt.Sprintf("Closest match: %d characters (%s)\n",
+			stats.BestPartial, stats.BestPartialAddress))
+	}
+
+	// Trailing empty buckets are left out
+	last := len(stats.Histogram) - 1
+	for last >= 0 && stats.Histogram[last] == 0 {
+		last--
+	}
+	if last >= 0 {
+		builder.WriteString("Partial match lengths:\n")
+		for n := 0; n <= last; n++ {
+			builder.WriteString(fmt.Sprintf("  %2d: %d\n", n, stats.Histogram[n]))
+		}
+	}
+
 	return builder.String()
 }
 
@@ -10,6 +10,8 @@ import (
 )
 
 func TestFormatResults(t *testing.T) {
+	nonce := uint64(3)
+	index := uint32(42)
 	sampleResults := []vanity.Result{
 		{
 			Address:    "init1test123",
@@ -20,6 +22,68 @@ func TestFormatResults(t *testing.T) {
 			Address:    "init1test456",
 			PrivateKey: "privatekey2",
 			PublicKey:  "publickey2",
+			Pattern:    "test",
+			Variant:    "7e57",
+			Distance:   1,
+			Score:      4,
+			Attempt:    1234,
+			Elapsed:    1.5,
+			Worker:     2,
+			Luck:       0.75,
+		},
+		{
+			Address: "0xcafe",
+			Creator: "0x1",
+			Seed:    "00112233",
+		},
+		{
+			Address:       "0x0000abcd",
+			HexAddress:    "0x0000AbCd",
+			Bech32Address: "init1qqqq",
+			Deployer:      "0xdeadbeef",
+			Salt:          "0x01",
+		},
+		{
+			Address:    "0xc0ffee",
+			PrivateKey: "privatekey4",
+			Deployer:   "0xFeedBeef",
+			Nonce:      &nonce,
+		},
+		{
+			Address:    "init1qqdanceqq",
+			PrivateKey: "privatekey3",
+			PublicKey:  "publickey3",
+			Word:       "dance",
+			Offset:     7,
+		},
+		{
+			Address:        "initvaloper1dew",
+			AccountAddress: "init1dew",
+			PrivateKey:     "privatekey5",
+		},
+		{
+			Address:     "initvalcons1dew",
+			KeyFileName: "priv_validator_key.json",
+			KeyFile:     []byte(`{"address":"AB"}`),
+		},
+		{
+			Address:        "init1dao",
+			PrivateKey:     "privatekey6",
+			PublicKey:      "publickey6",
+			MultisigPubKey: "multisig6",
+		},
+		{
+			Address:        "init1watch",
+			PublicKey:      "publickey7",
+			Index:          &index,
+			DerivationPath: "m/44'/118'/0'/0/42",
+		},
+		{
+			Address:        "init1kana",
+			PrivateKey:     "privatekey8",
+			Mnemonic:       "あいこくしん　あおぞら",
+			Language:       "japanese",
+			DerivationPath: "m/44'/118'/0'/0/0",
 		},
 	}
 
@@ -47,6 +111,29 @@ func TestFormatResults(t *testing.T) {
 					"Private key: privatekey1",
 					"Public key: publickey1",
 					"Address: init1test456",
+					"Pattern: test",
+					"Variant: 7e57",
+					"Distance: 1",
+					"Score: 4",
+					"Word: dance (offset 7)",
+					"Creator: 0x1",
+					"Seed: 00112233",
+					`object::create_named_object(creator, b"00112233")`,
+					"Hex address: 0x0000AbCd",
+					"Bech32 address: init1qqqq",
+					"Deployer: 0xdeadbeef",
+					"Salt: 0x01",
+					"Deployer: 0xFeedBeef",
+					"Nonce: 3",
+					"Found: attempt 1234 after 1.50s by worker 2 (luck 0.75)",
+					"Address: initvaloper1dew",
+					"Account address: init1dew",
+					"Key file: priv_validator_key.json",
+					"Multisig public key: multisig6",
+					"Index: 42",
+					"Derivation path: m/44'/118'/0'/0/42",
+					"Mnemonic: あいこくしん　あおぞら",
+					"Mnemonic language: japanese",
 				}
 				for _, exp := range expected {
 					if !strings.Contains(output, exp) {
@@ -65,8 +152,14 @@ func TestFormatResults(t *testing.T) {
 				if err := json.Unmarshal([]byte(output), &results); err != nil {
 					return err
 				}
-				if len(results) != 2 {
-					t.Errorf("expected 2 results, got %d", len(results))
+				if len(results) != 11 {
+					t.Errorf("expected 11 results, got %d", len(results))
+				}
+				if results[4].Nonce == nil || *results[4].Nonce != 3 {
+					t.Errorf("nonce not preserved: %v", results[4].Nonce)
+				}
+				if results[1].Attempt != 1234 || results[1].Worker != 2 || results[1].Luck != 0.75 {
+					t.Errorf("search metadata not preserved: %+v", results[1])
 				}
 				return nil
 			},
@@ -99,8 +192,14 @@ func TestFormatResults(t *testing.T) {
 
 func TestFormatStats(t *testing.T) {
 	stats := vanity.Stats{
-		Attempts: 1000,
-		Found:    5,
+		Attempts:           1000,
+		Found:              5,
+		Rejected:           2,
+		BestPartial:        4,
+		BestPartialAddress: "init1dewmqq",
+		Histogram:          []uint64{960, 30, 8, 1, 1, 0, 0},
+		StartTime:          time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixNano(),
+		EndTime:            time.Date(2024, 1, 2, 3, 4, 7, 0, time.UTC).UnixNano(),
 	}
 	duration := 2 * time.Second
 
@@ -108,11 +207,18 @@ func TestFormatStats(t *testing.T) {
 	output := f.FormatStats(stats, duration)
 
 	expectedStrings := []string{
+		"Started:",
+		"Finished:",
 		"Duration:",
 		"Total attempts: 1000",
 		"Addresses found: 5",
+		"Rejected by exclusions: 2",
 		"Average speed:",
 		"Attempts per match:",
+		"Closest match: 4 characters (init1dewmqq)",
+		"Partial match lengths:",
+		"   0: 960",
+		"   4: 1",
 	}
 
 	for _, exp := range expectedStrings {
@@ -120,4 +226,36 @@ func TestFormatStats(t *testing.T) {
 			t.Errorf("FormatStats() output missing '%s'", exp)
 		}
 	}
+	if strings.Contains(output, "   5:") {
+		t.Error("FormatStats() output includes trailing empty histogram buckets")
+	}
+}
+
+func TestFormatStatsJSON(t *testing.T) {
+	stats := vanity.Stats{
+		Attempts:  1000,
+		Found:     5,
+		StartTime: 1700000000000000000,
+		EndTime:   1700000002000000000,
+	}
+
+	f := NewFormatter("json", false)
+	output := f.FormatStats(stats, 2*time.Second)
+
+	var report struct {
+		Attempts  uint64  `json:"attempts"`
+		StartTime int64   `json:"start_time_ns"`
+		EndTime   int64   `json:"end_time_ns"`
+		Duration  float64 `json:"duration_seconds"`
+		Speed     float64 `json:"speed"`
+	}
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("FormatStats() output is not JSON: %v", err)
+	}
+	if report.Attempts != 1000 || report.StartTime != stats.StartTime || report.EndTime != stats.EndTime {
+		t.Errorf("FormatStats() = %+v, want stats preserved", report)
+	}
+	if report.Duration != 2 || report.Speed != 500 {
+		t.Errorf("FormatStats() duration = %v, speed = %v, want 2 and 500", report.Duration, report.Speed)
+	}
 }
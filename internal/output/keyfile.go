@@ -0,0 +1,45 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/degenhousedefi/initia-vanity/pkg/vanity"
+)
+
+// WriteKeyFiles writes the key file of each result that has one to
+// dir/<address>/<key file name> and returns the paths written. Existing
+// files are never overwritten, and a result whose key file failed to encode
+// is an error.
+func WriteKeyFiles(dir string, results []vanity.Result) ([]string, error) {
+	var paths []string
+	for _, result := range results {
+		if result.KeyFileName == "" {
+			continue
+		}
+		if len(result.KeyFile) == 0 {
+			return paths, fmt.Errorf("error encoding %s for %s", result.KeyFileName, result.Address)
+		}
+
+		keyDir := filepath.Join(dir, result.Address)
+		if err := os.MkdirAll(keyDir, 0700); err != nil {
+			return paths, fmt.Errorf("error creating key directory: %v", err)
+		}
+
+		path := filepath.Join(keyDir, result.KeyFileName)
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return paths, fmt.Errorf("error creating key file: %v", err)
+		}
+		_, err = file.Write(append(result.KeyFile, '\n'))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return paths, fmt.Errorf("error writing key file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/degenhousedefi/initia-vanity/pkg/vanity"
+)
+
+func TestWriteKeyFiles(t *testing.T) {
+	dir := t.TempDir()
+	results := []vanity.Result{
+		{Address: "init1dew"},
+		{
+			Address:     "initvalcons1dew",
+			KeyFileName: vanity.ValidatorKeyFile,
+			KeyFile:     []byte(`{"address":"AB"}`),
+		},
+	}
+
+	paths, err := WriteKeyFiles(dir, results)
+	if err != nil {
+		t.Fatalf("WriteKeyFiles() error = %v", err)
+	}
+	want := filepath.Join(dir, "initvalcons1dew", vanity.ValidatorKeyFile)
+	if len(paths) != 1 || paths[0] != want {
+		t.Fatalf("WriteKeyFiles() = %v, want [%s]", paths, want)
+	}
+
+	data, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{\"address\":\"AB\"}\n" {
+		t.Errorf("key file = %q", data)
+	}
+	info, err := os.Stat(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("key file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A second run must not overwrite the key
+	if _, err := WriteKeyFiles(dir, results); err == nil {
+		t.Error("WriteKeyFiles() overwrote an existing key file")
+	}
+
+	// A key file that failed to encode must not be written empty
+	missing := []vanity.Result{{Address: "initvalcons1qqq", KeyFileName: vanity.ValidatorKeyFile}}
+	if _, err := WriteKeyFiles(dir, missing); err == nil {
+		t.Error("WriteKeyFiles() accepted a result without key file contents")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "initvalcons1qqq")); !os.IsNotExist(err) {
+		t.Error("WriteKeyFiles() created a directory for a missing key file")
+	}
+}
@@ -0,0 +1,173 @@
+package vanity
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Scoring modes for best-of searches
+const (
+	// ScorePattern counts how many leading characters of the pattern appear
+	// at the pattern's position
+	ScorePattern = "pattern"
+	// ScoreLeading counts the repeated characters at the start of the address
+	ScoreLeading = "leading"
+	// ScoreRun is the longest run of a repeated character anywhere
+	ScoreRun = "run"
+	// ScoreZeroBytes counts the leading zero bytes of the raw address
+	ScoreZeroBytes = "zero-bytes"
+	// ScoreZeroNibbles counts the leading zero nibbles of the raw address
+	ScoreZeroNibbles = "zero-nibbles"
+)
+
+// NewBestGenerator creates a generator that scores every candidate and keeps
+// the top addresses seen so far instead of stopping at an exact match. It
+// runs until Stop is called, or with ScorePattern until every slot holds a
+// full match. The pattern is only used by ScorePattern and may be empty
+// otherwise.
+func NewBestGenerator(pattern Pattern, scoring string, top int, caseSensitive bool, useMnemonic bool, mnemonic string) *Generator {
+	var patterns []Pattern
+	if pattern.Value != "" {
+		patterns = []Pattern{{Value: pattern.Value, Position: pattern.Position}}
+	}
+
+	g := NewMultiGenerator(patterns, caseSensitive, useMnemonic, mnemonic)
+	g.count = top
+	g.scoring = scoring
+	if scoring == ScorePattern && len(patterns) > 0 {
+		g.maxScore = g.windows[0].size()
+	}
+	return g
+}
+
+// score rates an address under the generator's scoring mode
+func (g *Generator) score(address string) int {
+	if !g.caseSensitive {
+		address = strings.ToLower(address)
+	}
+	data := dataPart(address)
+
+	switch g.scoring {
+	case ScorePattern:
+		if len(g.patterns) == 0 {
+			return 0
+		}
+		return scorePattern(g.windows[0], g.patterns[0].Position, data)
+	case ScoreLeading:
+		return scoreLeading(data)
+	case ScoreRun:
+		return scoreRun(data)
+	case ScoreZeroBytes:
+		return scoreZeroNibbles(decodeAddress(address)) / 2
+	case ScoreZeroNibbles:
+		return scoreZeroNibbles(decodeAddress(address))
+	default:
+		return 0
+	}
+}
+
+// scorePattern returns the length of the longest prefix of w that matches
+// data at the given position. At the end position the prefix must end the
+// address, so a partial match still reads as a truncated pattern.
+func scorePattern(w window, position string, data string) int {
+	for n := w.size(); n > 0; n-- {
+		if _, ok := findWindow(w.prefix(n), position, data); ok {
+			return n
+		}
+	}
+	return 0
+}
+
+// scoreLeading returns the number of repeated characters at the start of data
+func scoreLeading(data string) int {
+	n := 0
+	for n < len(data) && data[n] == data[0] {
+		n++
+	}
+	return n
+}
+
+// scoreRun returns the length of the longest run of a repeated character
+func scoreRun(data string) int {
+	best, run := 0, 0
+	for i := 0; i < len(data); i++ {
+		if i > 0 && data[i] == data[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		if run > best {
+			best = run
+		}
+	}
+	return best
+}
+
+// scoreZeroNibbles returns the number of leading zero nibbles in raw
+func scoreZeroNibbles(raw []byte) int {
+	n := 0
+	for _, b := range raw {
+		if b != 0 {
+			if b < 0x10 {
+				n++
+			}
+			break
+		}
+		n += 2
+	}
+	return n
+}
+
+// SetTarget stops a best-of search as soon as the top address scores at
+// least target. It must be called before Generate.
+func (g *Generator) SetTarget(target int) {
+	g.target = target
+}
+
+// offer inserts a scored result into the leaderboard if it ranks among the
+// top results. Ties keep the earlier result ahead.
+func (g *Generator) offer(result Result) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	pos := len(g.results)
+	for pos > 0 && g.results[pos-1].Score < result.Score {
+		pos--
+	}
+	if pos >= g.count {
+		return
+	}
+
+	g.results = append(g.results, Result{})
+	copy(g.results[pos+1:], g.results[pos:])
+	g.results[pos] = result
+	if len(g.results) > g.count {
+		g.results = g.results[:g.count]
+	}
+	if len(g.results) == g.count {
+		g.threshold.Store(int64(g.results[len(g.results)-1].Score))
+	}
+
+	atomic.StoreUint64(&g.stats.Found, uint64(len(g.results)))
+	g.boardVersion.Add(1)
+}
+
+// bestScore returns the top score on the leaderboard
+func (g *Generator) bestScore() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.results) == 0 {
+		return 0
+	}
+	return g.results[0].Score
+}
+
+// printLeaderboard prints the current leaderboard
+func (g *Generator) printLeaderboard() {
+	board := g.GetResults()
+	fmt.Printf("\rLeaderboard:%s\n", strings.Repeat(" ", 60))
+	for i, result := range board {
+		fmt.Printf("  %2d. [%d] %s\n", i+1, result.Score, result.Address)
+	}
+}
@@ -0,0 +1,132 @@
+package vanity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		scoring string
+		pattern Pattern
+		address string
+		want    int
+	}{
+		{name: "pattern at start", scoring: ScorePattern, pattern: Pattern{Value: "dewmud", Position: "start"}, address: "init1dewmxqqq", want: 4},
+		{name: "pattern at end", scoring: ScorePattern, pattern: Pattern{Value: "dewmud", Position: "end"}, address: "init1qqqqdewm", want: 4},
+		{name: "pattern anywhere", scoring: ScorePattern, pattern: Pattern{Value: "dewmud", Position: "any"}, address: "init1qdewqqdewmuq", want: 5},
+		{name: "pattern no match", scoring: ScorePattern, pattern: Pattern{Value: "dewmud", Position: "start"}, address: "init1qqqqqq", want: 0},
+		{name: "leading", scoring: ScoreLeading, address: "init1qqqqpzz", want: 4},
+		{name: "run", scoring: ScoreRun, address: "init1qqpzzzzzr", want: 5},
+		{name: "zero bytes hex", scoring: ScoreZeroBytes, address: "0x0000000a1b", want: 3},
+		{name: "zero nibbles hex", scoring: ScoreZeroNibbles, address: "0x0000000a1b", want: 7},
+		{name: "zero nibbles bech32", scoring: ScoreZeroNibbles, address: "init1qq9pz5a3lwk", want: 3},
+		{name: "zero bytes undecodable", scoring: ScoreZeroBytes, address: "init1qqqqqq", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewBestGenerator(tt.pattern, tt.scoring, 3, false, false, "")
+			if got := g.score(tt.address); got != tt.want {
+				t.Errorf("score() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreZeroNibbles(t *testing.T) {
+	tests := []struct {
+		raw  []byte
+		want int
+	}{
+		{nil, 0},
+		{[]byte{0x12, 0x00}, 0},
+		{[]byte{0x01, 0x00}, 1},
+		{[]byte{0x00, 0x10}, 2},
+		{[]byte{0x00, 0x00, 0x0f}, 5},
+		{[]byte{0x00, 0x00}, 4},
+	}
+
+	for _, tt := range tests {
+		if got := scoreZeroNibbles(tt.raw); got != tt.want {
+			t.Errorf("scoreZeroNibbles(%x) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestSetTarget(t *testing.T) {
+	g := NewBestGenerator(Pattern{}, ScoreZeroNibbles, 3, false, false, "")
+	g.SetTarget(4)
+
+	g.offer(Result{Address: "a", Score: 3})
+	if g.complete() {
+		t.Error("complete() = true below the target")
+	}
+	g.offer(Result{Address: "b", Score: 4})
+	if !g.complete() {
+		t.Error("complete() = false once the target is reached")
+	}
+}
+
+func TestOffer(t *testing.T) {
+	g := NewBestGenerator(Pattern{}, ScoreRun, 3, false, false, "")
+	for i, score := range []int{2, 5, 3, 5, 1, 4} {
+		g.offer(Result{Address: string(rune('a' + i)), Score: score})
+	}
+
+	board := g.GetResults()
+	want := []string{"b", "d", "f"}
+	if len(board) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(board))
+	}
+	for i, result := range board {
+		if result.Address != want[i] {
+			t.Errorf("entry %d = %s (score %d), want %s", i, result.Address, result.Score, want[i])
+		}
+	}
+	if got := g.threshold.Load(); got != 4 {
+		t.Errorf("threshold = %d, want 4", got)
+	}
+}
+
+func TestGenerateBest(t *testing.T) {
+	g := NewBestGenerator(Pattern{}, ScoreRun, 3, false, false, "")
+	time.AfterFunc(300*time.Millisecond, g.Stop)
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	board := g.GetResults()
+	if len(board) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(board))
+	}
+	for i := 1; i < len(board); i++ {
+		if board[i].Score > board[i-1].Score {
+			t.Errorf("leaderboard is not sorted: %d before %d", board[i-1].Score, board[i].Score)
+		}
+	}
+}
+
+func TestGenerateBestStopsOnFullMatch(t *testing.T) {
+	g := NewBestGenerator(Pattern{Value: "q", Position: "end"}, ScorePattern, 2, false, false, "")
+
+	done := make(chan struct{})
+	go func() {
+		g.Generate(2)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		g.Stop()
+		t.Fatal("generator did not stop once every slot held a full match")
+	}
+	for _, result := range g.GetResults() {
+		if result.Score != 1 {
+			t.Errorf("expected full match score 1, got %d", result.Score)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package vanity
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Pattern classes describe a shape rather than a literal, written as
+// name:N where N is the number of characters
+const (
+	ClassRepeat     = "repeat"     // one character repeated N times
+	ClassPalindrome = "palindrome" // N characters reading the same backwards
+	ClassAscending  = "ascending"  // N consecutive characters of the alphabet
+	ClassDigits     = "digits"     // N digits
+)
+
+// class is a compiled pattern class over the characters of alphabet
+type class struct {
+	name     string
+	n        int
+	alphabet string
+}
+
+// compileClass parses the name and length of a pattern class
+func compileClass(name, arg, alphabet string) (window, error) {
+	switch name {
+	case ClassRepeat, ClassPalindrome, ClassAscending, ClassDigits:
+	default:
+		return nil, fmt.Errorf("unknown pattern class '%s': must be one of: repeat, palindrome, ascending, digits", name)
+	}
+
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid length '%s' for pattern class '%s'", arg, name)
+	}
+	if name == ClassAscending && n > len(alphabet) {
+		return nil, fmt.Errorf("ascending runs can be at most %d characters", len(alphabet))
+	}
+
+	return class{name: name, n: n, alphabet: alphabet}, nil
+}
+
+func (c class) size() int {
+	return c.n
+}
+
+func (c class) prefix(n int) window {
+	return class{name: c.name, n: n, alphabet: c.alphabet}
+}
+
+func (c class) suffix(n int) window {
+	return class{name: c.name, n: n, alphabet: c.alphabet}
+}
+
+func (c class) matchAt(data string, start int) bool {
+	if start < 0 || start+c.n > len(data) {
+		return false
+	}
+	w := data[start : start+c.n]
+
+	switch c.name {
+	case ClassRepeat:
+		return strings.Count(w, w[:1]) == c.n
+	case ClassPalindrome:
+		for i := 0; i < c.n/2; i++ {
+			if w[i] != w[c.n-1-i] {
+				return false
+			}
+		}
+		return true
+	case ClassAscending:
+		first := strings.IndexByte(c.alphabet, w[0])
+		return first >= 0 && first+c.n <= len(c.alphabet) && c.alphabet[first:first+c.n] == w
+	case ClassDigits:
+		for i := 0; i < c.n; i++ {
+			if w[i] < '0' || w[i] > '9' {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func (c class) probability(alphabet string) float64 {
+	k := float64(len(alphabet))
+	switch c.name {
+	case ClassRepeat:
+		return math.Pow(k, float64(1-c.n))
+	case ClassPalindrome:
+		return math.Pow(k, -float64(c.n/2))
+	case ClassAscending:
+		return (k - float64(c.n) + 1) * math.Pow(k, -float64(c.n))
+	case ClassDigits:
+		digits := 0
+		for i := 0; i < len(alphabet); i++ {
+			if alphabet[i] >= '0' && alphabet[i] <= '9' {
+				digits++
+			}
+		}
+		return math.Pow(float64(digits)/k, float64(c.n))
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,95 @@
+package vanity
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompileClass(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "repeat:5"},
+		{value: "palindrome:6"},
+		{value: "ascending:4"},
+		{value: "digits:8"},
+		{value: "sparkly:4", wantErr: true},
+		{value: "repeat:0", wantErr: true},
+		{value: "repeat:x", wantErr: true},
+		{value: "ascending:33", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if err := ValidatePattern(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClassMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		position string
+		address  string
+		want     bool
+	}{
+		{pattern: "repeat:4", position: "start", address: "init1qqqqpz", want: true},
+		{pattern: "repeat:4", position: "start", address: "init1qqqpzz", want: false},
+		{pattern: "repeat:4", position: "end", address: "init1pz7777", want: true},
+		{pattern: "repeat:4", position: "any", address: "init1pzzzzr", want: true},
+		{pattern: "palindrome:5", position: "start", address: "init1xyzyxq", want: true},
+		{pattern: "palindrome:4", position: "end", address: "init1qxyyx", want: true},
+		{pattern: "palindrome:4", position: "end", address: "init1qxyxy", want: false},
+		{pattern: "ascending:4", position: "start", address: "init1qpzrxx", want: true},
+		{pattern: "ascending:4", position: "any", address: "init1xxgf2txx", want: true},
+		{pattern: "ascending:4", position: "any", address: "init1xxgf2xxx", want: false},
+		{pattern: "digits:3", position: "end", address: "init1qq907", want: true},
+		{pattern: "digits:3", position: "end", address: "init1qq9q7", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.address, func(t *testing.T) {
+			g := NewGenerator(tt.pattern, tt.position, false, 1, false, "")
+			if got := g.isMatch(tt.address); got != tt.want {
+				t.Errorf("isMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassDifficulty(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    float64
+	}{
+		{pattern: "repeat:3", want: 32 * 32},
+		{pattern: "palindrome:5", want: 32 * 32},
+		{pattern: "ascending:2", want: 32 * 32 / 31.0},
+		{pattern: "digits:2", want: 32 * 32 / 81.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			g := NewGenerator(tt.pattern, "start", false, 1, false, "")
+			if got := g.Difficulty(); math.Abs(got-tt.want) > 1e-6*tt.want {
+				t.Errorf("Difficulty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateClass(t *testing.T) {
+	g := NewGenerator("repeat:2", "end", false, 2, false, "")
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, result := range g.GetResults() {
+		n := len(result.Address)
+		if result.Address[n-1] != result.Address[n-2] {
+			t.Errorf("address does not end with a repeated character: %s", result.Address)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package vanity
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// Ed25519PubKeyType is the type URL of Cosmos SDK ed25519 public keys, the
+// form create-validator takes its consensus key in
+const Ed25519PubKeyType = "/cosmos.crypto.ed25519.PubKey"
+
+// ValidatorKeyFile is the name CometBFT gives a validator's consensus key
+const ValidatorKeyFile = "priv_validator_key.json"
+
+// PrivValidatorKey returns the priv_validator_key.json document for a
+// consensus key, with the address, pub_key and priv_key in CometBFT's amino
+// JSON
+func PrivValidatorKey(privKey ed25519.PrivKey) ([]byte, error) {
+	pubKey := privKey.PubKey()
+	return cmtjson.MarshalIndent(privval.FilePVKey{
+		Address: pubKey.Address(),
+		PubKey:  pubKey,
+		PrivKey: privKey,
+	}, "", "  ")
+}
+
+// consensusSource generates ed25519 consensus keys and yields their
+// initvalcons1 addresses
+type consensusSource struct {
+	g *Generator
+}
+
+func (s consensusSource) next(yield yieldFunc) error {
+	privKey, err := s.g.newEd25519Key()
+	if err != nil {
+		return err
+	}
+	pubKey := privKey.PubKey()
+
+	address, err := bech32.ConvertAndEncode(ValconsHRP, pubKey.Address())
+	if err != nil {
+		return err
+	}
+
+	yield(candidate{address: address, fill: func(r *Result) {
+		pubKeyJSON, _ := json.Marshal(map[string]interface{}{
+			"@type": Ed25519PubKeyType,
+			"key":   base64.StdEncoding.EncodeToString(pubKey.Bytes()),
+		})
+		r.PrivateKey = hex.EncodeToString(privKey.Bytes())
+		r.PublicKey = string(pubKeyJSON)
+		r.KeyFileName = ValidatorKeyFile
+		// A key that fails to encode leaves KeyFile empty, which
+		// output.WriteKeyFiles reports
+		if keyFile, err := PrivValidatorKey(privKey); err == nil {
+			r.KeyFile = keyFile
+		}
+	}})
+	return nil
+}
+
+func (consensusSource) alphabet() string {
+	return Bech32Charset
+}
+
+func (consensusSource) dataLen() int {
+	return addressDataLen
+}
+
+// SetConsensus switches the generator to ed25519 validator consensus keys,
+// matched on their initvalcons1 address. Results carry the key as a
+// priv_validator_key.json document. It must be called before Generate.
+func (g *Generator) SetConsensus() {
+	g.setSource(consensusSource{g: g})
+}
@@ -0,0 +1,83 @@
+package vanity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+func TestPrivValidatorKey(t *testing.T) {
+	privKey := ed25519.GenPrivKeyFromSecret([]byte("initia-vanity"))
+
+	keyFile, err := PrivValidatorKey(privKey)
+	if err != nil {
+		t.Fatalf("PrivValidatorKey() error = %v", err)
+	}
+
+	var doc struct {
+		Address string `json:"address"`
+		PubKey  struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"pub_key"`
+		PrivKey struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"priv_key"`
+	}
+	if err := json.Unmarshal(keyFile, &doc); err != nil {
+		t.Fatalf("key file is not JSON: %v", err)
+	}
+	if doc.Address != privKey.PubKey().Address().String() {
+		t.Errorf("address = %s, want %s", doc.Address, privKey.PubKey().Address())
+	}
+	if doc.PubKey.Type != ed25519.PubKeyName || doc.PrivKey.Type != ed25519.PrivKeyName {
+		t.Errorf("key types = %s, %s", doc.PubKey.Type, doc.PrivKey.Type)
+	}
+
+	// CometBFT must be able to load the file back
+	var pvKey privval.FilePVKey
+	if err := cmtjson.Unmarshal(keyFile, &pvKey); err != nil {
+		t.Fatalf("CometBFT cannot read key file: %v", err)
+	}
+	if !pvKey.PrivKey.Equals(privKey) {
+		t.Error("key file holds a different private key")
+	}
+}
+
+func TestGenerateConsensus(t *testing.T) {
+	g := NewGenerator("q", "start", false, 1, false, "")
+	g.SetConsensus()
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	result := g.GetResults()[0]
+	if !strings.HasPrefix(result.Address, ValconsHRP+"1q") {
+		t.Errorf("address does not match: %s", result.Address)
+	}
+	if result.KeyFileName != ValidatorKeyFile {
+		t.Errorf("key file name = %s, want %s", result.KeyFileName, ValidatorKeyFile)
+	}
+
+	var pvKey privval.FilePVKey
+	if err := cmtjson.Unmarshal(result.KeyFile, &pvKey); err != nil {
+		t.Fatalf("CometBFT cannot read key file: %v", err)
+	}
+	address, err := bech32.ConvertAndEncode(ValconsHRP, pvKey.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != result.Address {
+		t.Errorf("key file address %s, want %s", address, result.Address)
+	}
+	if !strings.Contains(result.PublicKey, Ed25519PubKeyType) {
+		t.Errorf("public key = %s", result.PublicKey)
+	}
+}
@@ -0,0 +1,45 @@
+package vanity
+
+import "strings"
+
+// constraintNames maps match positions to the names used for constraints
+var constraintNames = map[string]string{
+	"start": "prefix",
+	"end":   "suffix",
+	"any":   "contains",
+}
+
+// String returns the pattern written as a constraint, e.g. "prefix:dao"
+func (p Pattern) String() string {
+	if name, ok := constraintNames[p.Position]; ok {
+		return name + ":" + p.Value
+	}
+	return p.Value
+}
+
+// describeConstraints joins constraints with the given operator
+func describeConstraints(patterns []Pattern, op string) string {
+	parts := make([]string, len(patterns))
+	for i, p := range patterns {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, op)
+}
+
+// NewConstraintGenerator creates a generator that combines several
+// constraints into a single target. With requireAll set an address must
+// satisfy every constraint, e.g. both a prefix and a suffix; otherwise any
+// one constraint is enough. The Count of each constraint is ignored and
+// generation stops after count matching addresses.
+func NewConstraintGenerator(constraints []Pattern, requireAll bool, caseSensitive bool, count int, useMnemonic bool, mnemonic string) *Generator {
+	patterns := make([]Pattern, len(constraints))
+	for i, c := range constraints {
+		patterns[i] = Pattern{Value: c.Value, Position: c.Position}
+	}
+
+	g := NewMultiGenerator(patterns, caseSensitive, useMnemonic, mnemonic)
+	g.count = count
+	g.constraints = true
+	g.requireAll = requireAll
+	return g
+}
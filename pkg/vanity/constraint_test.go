@@ -0,0 +1,79 @@
+package vanity
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestConstraintMatch(t *testing.T) {
+	constraints := []Pattern{
+		{Value: "dew", Position: "start"},
+		{Value: "dew", Position: "end"},
+	}
+
+	tests := []struct {
+		name       string
+		requireAll bool
+		address    string
+		want       bool
+	}{
+		{name: "and both", requireAll: true, address: "init1dewqqqqdew", want: true},
+		{name: "and prefix only", requireAll: true, address: "init1dewqqqqqqq", want: false},
+		{name: "and suffix only", requireAll: true, address: "init1qqqqqqqdew", want: false},
+		{name: "or prefix only", requireAll: false, address: "init1dewqqqqqqq", want: true},
+		{name: "or suffix only", requireAll: false, address: "init1qqqqqqqdew", want: true},
+		{name: "or neither", requireAll: false, address: "init1qqqqqqqqqq", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewConstraintGenerator(constraints, tt.requireAll, false, 1, false, "")
+			if got := g.satisfied(g.matchPatterns(tt.address)); got != tt.want {
+				t.Errorf("satisfied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintDifficulty(t *testing.T) {
+	constraints := []Pattern{
+		{Value: "qq", Position: "start"},
+		{Value: "pp", Position: "end"},
+	}
+
+	and := NewConstraintGenerator(constraints, true, false, 1, false, "").Difficulty()
+	if want := math.Pow(32, 4); math.Abs(and-want) > 1e-6*want {
+		t.Errorf("AND difficulty = %v, want %v", and, want)
+	}
+
+	or := NewConstraintGenerator(constraints, false, false, 1, false, "").Difficulty()
+	if want := math.Pow(32, 2) / 2; math.Abs(or-want) > 1e-6*want {
+		t.Errorf("OR difficulty = %v, want %v", or, want)
+	}
+}
+
+func TestGenerateConstraints(t *testing.T) {
+	constraints := []Pattern{
+		{Value: "q", Position: "start"},
+		{Value: "p", Position: "end"},
+	}
+	g := NewConstraintGenerator(constraints, true, false, 2, false, "")
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	results := g.GetResults()
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if !strings.HasPrefix(result.Address, "init1q") || !strings.HasSuffix(result.Address, "p") {
+			t.Errorf("address does not satisfy all constraints: %s", result.Address)
+		}
+		if result.Pattern != "prefix:q AND suffix:p" {
+			t.Errorf("unexpected pattern description: %s", result.Pattern)
+		}
+	}
+}
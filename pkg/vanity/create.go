@@ -0,0 +1,108 @@
+package vanity
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// EthSecp256k1PubKeyType is the type URL of Initia's Ethereum-style
+// secp256k1 public keys
+const EthSecp256k1PubKeyType = "/initia.crypto.v1beta1.ethsecp256k1.PubKey"
+
+// EthAddress returns the 20-byte Ethereum address of a public key, the last
+// 20 bytes of the Keccak-256 hash of its uncompressed encoding
+func EthAddress(pub *secp256k1.PublicKey) []byte {
+	return keccak256(pub.SerializeUncompressed()[1:])[12:]
+}
+
+// CreateAddress returns the address of the contract that sender deploys with
+// plain CREATE at the given nonce, i.e. the last 20 bytes of
+// keccak256(rlp([sender, nonce]))
+func CreateAddress(sender []byte, nonce uint64) []byte {
+	// RLP of a nonce is a single byte below 0x80, and otherwise a length
+	// byte followed by the big-endian value without leading zeros. Zero is
+	// the empty string.
+	var item []byte
+	switch {
+	case nonce == 0:
+		item = []byte{0x80}
+	case nonce < 0x80:
+		item = []byte{byte(nonce)}
+	default:
+		var be []byte
+		for n := nonce; n > 0; n >>= 8 {
+			be = append([]byte{byte(n)}, be...)
+		}
+		item = append([]byte{0x80 + byte(len(be))}, be...)
+	}
+
+	payload := append([]byte{0x80 + byte(len(sender))}, sender...)
+	payload = append(payload, item...)
+	return keccak256(append([]byte{0xc0 + byte(len(payload))}, payload...))[12:]
+}
+
+// createSource generates eth_secp256k1 deployer keys and yields the address
+// of the contract each key would create at every nonce up to maxNonce
+type createSource struct {
+	g        *Generator
+	maxNonce uint64
+	encoding string
+}
+
+func (s createSource) next(yield yieldFunc) error {
+	key, err := s.g.newPrivKey()
+	if err != nil {
+		return err
+	}
+	privKey := secp256k1.PrivKeyFromBytes(key.Key)
+	pubKey := privKey.PubKey()
+	sender := EthAddress(pubKey)
+
+	pubKeyJSON, err := json.Marshal(map[string]interface{}{
+		"@type": EthSecp256k1PubKeyType,
+		"key":   base64.StdEncoding.EncodeToString(pubKey.SerializeCompressed()),
+	})
+	if err != nil {
+		return err
+	}
+
+	for nonce := uint64(0); nonce <= s.maxNonce; nonce++ {
+		addr := CreateAddress(sender, nonce)
+		address, err := encodeAddress(addr, s.encoding)
+		if err != nil {
+			return err
+		}
+		keep := yield(candidate{address: address, fill: func(r *Result) {
+			r.PrivateKey = hex.EncodeToString(privKey.Serialize())
+			r.PublicKey = string(pubKeyJSON)
+			r.Deployer = ChecksumAddress(sender)
+			r.Nonce = &nonce
+			r.HexAddress = ChecksumAddress(addr)
+			r.Bech32Address, _ = encodeAddress(addr, EncodingBech32)
+		}})
+		if !keep {
+			break
+		}
+	}
+	return nil
+}
+
+func (s createSource) alphabet() string {
+	return encodingAlphabet(s.encoding)
+}
+
+func (s createSource) dataLen() int {
+	return encodedLen(20, s.encoding)
+}
+
+// SetCreate switches the generator from account keys to eth_secp256k1
+// deployer keys, matched on the address of the contract each key deploys
+// with plain CREATE at any nonce from 0 to maxNonce. Results carry the key,
+// the deployer address and the nonce to deploy at. It must be called before
+// Generate.
+func (g *Generator) SetCreate(maxNonce uint64, encoding string) {
+	g.setSource(createSource{g: g, maxNonce: maxNonce, encoding: encoding})
+}
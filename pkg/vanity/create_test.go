@@ -0,0 +1,114 @@
+package vanity
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+func TestEthAddress(t *testing.T) {
+	raw, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	privKey := secp256k1.PrivKeyFromBytes(raw)
+
+	want := "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+	if got := ChecksumAddress(EthAddress(privKey.PubKey())); got != want {
+		t.Errorf("EthAddress() = %s, want %s", got, want)
+	}
+}
+
+func TestCreateAddress(t *testing.T) {
+	sender, _ := ParseEVMAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+
+	tests := []struct {
+		nonce uint64
+		want  string
+	}{
+		{0, "cd234a471b72ba2f1ccf0a70fcaba648a5eecd8d"},
+		{1, "343c43a37d37dff08ae8c4a11544c718abb4fcf8"},
+		{2, "f778b86fa74e846c4f0a1fbd1335fe81c00a0c91"},
+		{3, "fffd933a0bc612844eaf0c6fe3e5b8e9b6c1d19c"},
+	}
+
+	for _, tt := range tests {
+		if got := hex.EncodeToString(CreateAddress(sender, tt.nonce)); got != tt.want {
+			t.Errorf("CreateAddress(nonce %d) = %s, want %s", tt.nonce, got, tt.want)
+		}
+	}
+
+	// Multi-byte nonces must not collide with single-byte ones
+	if hex.EncodeToString(CreateAddress(sender, 0x80)) == hex.EncodeToString(CreateAddress(sender, 0)) {
+		t.Error("CreateAddress() collides for nonces 0x80 and 0")
+	}
+}
+
+func TestCreateSource(t *testing.T) {
+	src := createSource{g: &Generator{}, maxNonce: 3, encoding: EncodingHex}
+
+	var nonces []uint64
+	var deployer string
+	err := src.next(func(c candidate) bool {
+		var result Result
+		c.fill(&result)
+		if result.Nonce == nil {
+			t.Fatal("result has no nonce")
+		}
+		nonces = append(nonces, *result.Nonce)
+
+		if deployer == "" {
+			deployer = result.Deployer
+		} else if result.Deployer != deployer {
+			t.Errorf("deployer changed within one key: %s != %s", result.Deployer, deployer)
+		}
+
+		sender, _ := ParseEVMAddress(result.Deployer)
+		want := "0x" + hex.EncodeToString(CreateAddress(sender, *result.Nonce))
+		if c.address != want || !strings.EqualFold(result.HexAddress, want) {
+			t.Errorf("address = %s, want %s", c.address, want)
+		}
+
+		raw, _ := hex.DecodeString(result.PrivateKey)
+		if got := ChecksumAddress(EthAddress(secp256k1.PrivKeyFromBytes(raw).PubKey())); got != result.Deployer {
+			t.Errorf("private key belongs to %s, want %s", got, result.Deployer)
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatalf("next() error = %v", err)
+	}
+	if len(nonces) != 4 || nonces[0] != 0 || nonces[3] != 3 {
+		t.Errorf("nonces = %v, want 0 through 3", nonces)
+	}
+
+	// Stopping early skips the remaining nonces
+	calls := 0
+	src.next(func(candidate) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("yield called %d times after returning false, want 1", calls)
+	}
+}
+
+func TestGenerateCreate(t *testing.T) {
+	g := NewGenerator("a", "start", false, 2, false, "")
+	g.SetCreate(4, EncodingHex)
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	results := g.GetResults()
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if !strings.HasPrefix(result.Address, "0xa") {
+			t.Errorf("address does not start with a: %s", result.Address)
+		}
+		if result.Nonce == nil || *result.Nonce > 4 {
+			t.Errorf("nonce out of range: %v", result.Nonce)
+		}
+	}
+}
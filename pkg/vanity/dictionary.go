@@ -0,0 +1,61 @@
+package vanity
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Bech32Charset is the alphabet used by the data part of bech32 addresses
+const Bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// isBech32 reports whether s consists only of bech32 data characters
+func isBech32(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(Bech32Charset, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterWords reduces a wordlist to the distinct words that can appear in a
+// bech32 address and are at least minLength characters long. Words are
+// lowercased, and the result is ordered longest first.
+func FilterWords(words []string, minLength int) []string {
+	seen := make(map[string]bool)
+	var filtered []string
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if len(w) < minLength || len(w) == 0 || seen[w] || !isBech32(w) {
+			continue
+		}
+		seen[w] = true
+		filtered = append(filtered, w)
+	}
+
+	slices.SortFunc(filtered, func(a, b string) int {
+		if c := cmp.Compare(len(b), len(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return filtered
+}
+
+// NewDictionaryGenerator creates a generator that accepts any address
+// containing a word from the wordlist that is at least minLength characters
+// long. Words that cannot be spelled in bech32 are dropped. When an address
+// contains several words the longest one is reported.
+func NewDictionaryGenerator(words []string, minLength, count int, useMnemonic bool, mnemonic string) *Generator {
+	filtered := FilterWords(words, minLength)
+	patterns := make([]Pattern, len(filtered))
+	for i, w := range filtered {
+		patterns[i] = Pattern{Value: w, Position: "any"}
+	}
+
+	g := NewMultiGenerator(patterns, false, useMnemonic, mnemonic)
+	g.count = count
+	g.dictionary = true
+	return g
+}
@@ -0,0 +1,53 @@
+package vanity
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFilterWords(t *testing.T) {
+	words := []string{"Dance", "bird", "hello", "wax", "dance", "  zeta  ", "", "quest", "gym7"}
+	got := FilterWords(words, 4)
+	want := []string{"dance", "quest", "gym7", "zeta"}
+	if !slices.Equal(got, want) {
+		t.Errorf("FilterWords() = %v, want %v", got, want)
+	}
+}
+
+func TestDictionaryMatch(t *testing.T) {
+	g := NewDictionaryGenerator([]string{"dance", "nce", "quest"}, 3, 1, false, "")
+
+	hits := g.matchPatterns("init1qqdanceqq")
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 hits, got %v", hits)
+	}
+	// The longest word comes first
+	if word := g.patterns[hits[0].pattern].Value; word != "dance" {
+		t.Errorf("expected longest word 'dance', got '%s'", word)
+	}
+	if hits[0].offset != 7 {
+		t.Errorf("expected offset 7, got %d", hits[0].offset)
+	}
+}
+
+func TestGenerateDictionary(t *testing.T) {
+	g := NewDictionaryGenerator([]string{"ace", "dew", "mud", "gas", "cat"}, 3, 2, false, "")
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	results := g.GetResults()
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Word == "" {
+			t.Error("result is missing the matched word")
+		}
+		if !strings.HasPrefix(result.Address[result.Offset:], result.Word) {
+			t.Errorf("word %s not found at offset %d in %s", result.Word, result.Offset, result.Address)
+		}
+	}
+}
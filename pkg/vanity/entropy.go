@@ -0,0 +1,155 @@
+package vanity
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	dsecp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// SetEntropySource replaces crypto/rand as the source of every key, mnemonic,
+// seed and salt the generator draws, e.g. with an HSM-backed RNG or a
+// deterministic reader for reproducible tests. Reads are serialized, so the
+// source need not be safe for concurrent use, and pass the health tests. A
+// nil source restores crypto/rand. It must be called before Generate.
+func (g *Generator) SetEntropySource(r io.Reader) {
+	g.rand = r
+}
+
+// entropySource returns the configured entropy source or crypto/rand
+func (g *Generator) entropySource() io.Reader {
+	if g.rand == nil {
+		return rand.Reader
+	}
+	return g.rand
+}
+
+// SetUserEntropy mixes extra entropy, such as dice rolls, into everything the
+// generator draws: keys of every kind, mnemonics, object seeds and CREATE2
+// salts. Each draw is SHA-256(SHA-256(data) || 32 random bytes), so it is at
+// least as unpredictable as the stronger of the two sources. It must be
+// called before Generate.
+func (g *Generator) SetUserEntropy(data []byte) {
+	digest := sha256.Sum256(data)
+	g.userEntropy = digest[:]
+}
+
+// entropy returns n bytes of fresh key material, at most 32
+func (g *Generator) entropy(n int) ([]byte, error) {
+	buf := make([]byte, 32)
+	if err := g.health.read(g.entropySource(), buf); err != nil {
+		return nil, err
+	}
+	if g.userEntropy != nil {
+		sum := sha256.Sum256(append(append([]byte(nil), g.userEntropy...), buf...))
+		buf = sum[:]
+	}
+	return buf[:n], nil
+}
+
+// newPrivKey draws a secp256k1 private key from the generator's entropy,
+// retrying the rare draws that are not a valid scalar
+func (g *Generator) newPrivKey() (*secp256k1.PrivKey, error) {
+	for {
+		key, err := g.entropy(32)
+		if err != nil {
+			return nil, err
+		}
+		var scalar dsecp256k1.ModNScalar
+		if overflow := scalar.SetByteSlice(key); !overflow && !scalar.IsZero() {
+			if err := g.health.check(key); err != nil {
+				return nil, err
+			}
+			return &secp256k1.PrivKey{Key: key}, nil
+		}
+	}
+}
+
+// newEd25519Key draws an ed25519 private key from the generator's entropy
+func (g *Generator) newEd25519Key() (ed25519.PrivKey, error) {
+	seed, err := g.entropy(stded25519.SeedSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.health.check(seed); err != nil {
+		return nil, err
+	}
+	return ed25519.PrivKey(stded25519.NewKeyFromSeed(seed)), nil
+}
+
+// ParseDice parses a sequence of six-sided dice rolls, digits 1 to 6 with
+// optional whitespace between them, and returns the rolls as ASCII digits
+func ParseDice(rolls string) (string, error) {
+	var digits strings.Builder
+	for _, r := range rolls {
+		switch {
+		case unicode.IsSpace(r):
+		case r >= '1' && r <= '6':
+			digits.WriteRune(r)
+		default:
+			return "", fmt.Errorf("invalid dice roll %q: rolls must be digits 1 to 6", r)
+		}
+	}
+	if digits.Len() == 0 {
+		return "", fmt.Errorf("no dice rolls given")
+	}
+	return digits.String(), nil
+}
+
+// DiceBits returns the entropy of n rolls of a fair six-sided die
+func DiceBits(n int) float64 {
+	return float64(n) * math.Log2(6)
+}
+
+// AuditMnemonic derives a mnemonic from dice rolls alone, for entropy
+// ceremonies that must be reproducible: the entropy is the first bits of the
+// SHA-256 of the rolls as ASCII digits, e.g. `printf 1623... | sha256sum`.
+// There must be enough rolls to cover the mnemonic's entropy.
+func AuditMnemonic(rolls string, words int, language string) (string, error) {
+	digits, err := ParseDice(rolls)
+	if err != nil {
+		return "", err
+	}
+	bits, err := EntropyBits(words)
+	if err != nil {
+		return "", err
+	}
+	if need := int(math.Ceil(float64(bits) / math.Log2(6))); len(digits) < need {
+		return "", fmt.Errorf("%d dice rolls give %.1f bits of entropy; a %d-word mnemonic needs at least %d rolls",
+			len(digits), DiceBits(len(digits)), words, need)
+	}
+
+	sum := sha256.Sum256([]byte(digits))
+	return NewMnemonic(sum[:bits/8], language)
+}
+
+// AuditResult returns the result for the mnemonic AuditMnemonic derives from
+// dice rolls, i.e. its first account key
+func AuditResult(rolls string, words int, language string) (Result, error) {
+	mnemonic, err := AuditMnemonic(rolls, words, language)
+	if err != nil {
+		return Result{}, err
+	}
+
+	g := &Generator{mnemonic: mnemonic, language: language}
+	address, privKey, pubKey, mnemonic, path, err := g.generateAddressFromMnemonic()
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Address:        address,
+		PrivateKey:     privKey,
+		PublicKey:      pubKey,
+		Mnemonic:       mnemonic,
+		Language:       g.mnemonicLanguage(),
+		DerivationPath: path,
+	}, nil
+}
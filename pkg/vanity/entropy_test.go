@@ -0,0 +1,216 @@
+package vanity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	mathrand "math/rand"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/go-bip39"
+)
+
+func TestParseDice(t *testing.T) {
+	tests := []struct {
+		name    string
+		rolls   string
+		want    string
+		wantErr bool
+	}{
+		{"digits", "162534", "162534", false},
+		{"whitespace", "1 6 2\n5\t3 4", "162534", false},
+		{"zero", "1602", "", true},
+		{"seven", "17", "", true},
+		{"empty", "  ", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDice(tt.rolls)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDice() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditMnemonic(t *testing.T) {
+	rolls := strings.Repeat("3164 2552 65", 10)
+	digits, _ := ParseDice(rolls)
+
+	for _, words := range []int{12, 24} {
+		got, err := AuditMnemonic(rolls, words, LanguageEnglish)
+		if err != nil {
+			t.Fatalf("AuditMnemonic(%d words) error = %v", words, err)
+		}
+
+		// The mnemonic must be reproducible with sha256sum and any BIP39 tool
+		sum := sha256.Sum256([]byte(digits))
+		bits, _ := EntropyBits(words)
+		want, _ := bip39.NewMnemonic(sum[:bits/8])
+		if got != want {
+			t.Errorf("AuditMnemonic(%d words) = %q, want %q", words, got, want)
+		}
+	}
+
+	// 100 rolls are needed for 256 bits, 50 for 128
+	if _, err := AuditMnemonic(strings.Repeat("1", 99), 24, LanguageEnglish); err == nil {
+		t.Error("AuditMnemonic() accepted 99 rolls for 24 words")
+	}
+	if _, err := AuditMnemonic(strings.Repeat("1", 50), 12, LanguageEnglish); err != nil {
+		t.Errorf("AuditMnemonic() rejected 50 rolls for 12 words: %v", err)
+	}
+}
+
+func TestAuditResult(t *testing.T) {
+	rolls := strings.Repeat("6", 100)
+
+	first, err := AuditResult(rolls, 24, LanguageEnglish)
+	if err != nil {
+		t.Fatalf("AuditResult() error = %v", err)
+	}
+	second, _ := AuditResult(rolls, 24, LanguageEnglish)
+	if first.Address != second.Address || first.Mnemonic != second.Mnemonic {
+		t.Error("AuditResult() is not reproducible")
+	}
+	if !strings.HasPrefix(first.Address, "init1") || first.Language != LanguageEnglish {
+		t.Errorf("AuditResult() = %+v", first)
+	}
+}
+
+func TestUserEntropy(t *testing.T) {
+	g := NewGenerator("test", "end", false, 1, false, "")
+	g.SetUserEntropy([]byte("31642552"))
+
+	first, err := g.newPrivKey()
+	if err != nil {
+		t.Fatalf("newPrivKey() error = %v", err)
+	}
+	second, _ := g.newPrivKey()
+	if string(first.Key) == string(second.Key) {
+		t.Error("keys with the same user entropy are equal")
+	}
+
+	// Mnemonic mode draws from the same entropy
+	g = NewGenerator("test", "end", false, 1, true, "")
+	g.SetUserEntropy([]byte("31642552"))
+	if _, _, _, mnemonic, _, err := g.generateAddressFromMnemonic(); err != nil || len(strings.Fields(mnemonic)) != DefaultWords {
+		t.Errorf("generateAddressFromMnemonic() = %q, %v", mnemonic, err)
+	}
+}
+
+// sequence returns the bytes 0, 1, ..., n-1, a known stream that passes the
+// health tests
+func sequence(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+func TestEntropySource(t *testing.T) {
+	g := NewGenerator("test", "end", false, 1, false, "")
+	stream := sequence(48)
+	g.SetEntropySource(bytes.NewReader(stream))
+
+	_, privKey, _, err := g.generateAddress()
+	if err != nil {
+		t.Fatalf("generateAddress() error = %v", err)
+	}
+	if want := hex.EncodeToString(stream[:32]); privKey != want {
+		t.Errorf("private key = %s, want %s", privKey, want)
+	}
+
+	// The 16 bytes left are too few for another key
+	if _, _, _, err := g.generateAddress(); err == nil {
+		t.Error("generateAddress() succeeded on an exhausted source")
+	}
+
+	// A nil source restores crypto/rand
+	g.SetEntropySource(nil)
+	if _, _, _, err := g.generateAddress(); err != nil {
+		t.Errorf("generateAddress() error = %v", err)
+	}
+}
+
+func TestEntropySourceUserEntropy(t *testing.T) {
+	random := sequence(32)
+	g := NewGenerator("test", "end", false, 1, false, "")
+	g.SetEntropySource(bytes.NewReader(random))
+	g.SetUserEntropy([]byte("316425"))
+
+	got, err := g.entropy(32)
+	if err != nil {
+		t.Fatalf("entropy() error = %v", err)
+	}
+	digest := sha256.Sum256([]byte("316425"))
+	want := sha256.Sum256(append(digest[:], random...))
+	if !bytes.Equal(got, want[:]) {
+		t.Errorf("entropy() = %x, want %x", got, want)
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	modes := []struct {
+		name string
+		set  func(g *Generator)
+	}{
+		{"account", func(g *Generator) {}},
+		{"mnemonic", func(g *Generator) { g.useMnemonic = true }},
+		{"valcons", func(g *Generator) { g.SetConsensus() }},
+		{"node", func(g *Generator) { g.SetNode() }},
+		{"create", func(g *Generator) { g.SetCreate(0, EncodingHex) }},
+		{"create2", func(g *Generator) {
+			_ = g.SetCreate2("0x0000000000000000000000000000000000000001", "0x"+strings.Repeat("00", 32), EncodingHex)
+		}},
+	}
+
+	for _, mode := range modes {
+		t.Run(mode.name, func(t *testing.T) {
+			var results []Result
+			for i := 0; i < 2; i++ {
+				g := NewGenerator("a", "any", false, 1, false, "")
+				mode.set(g)
+				g.SetEntropySource(mathrand.New(mathrand.NewSource(1)))
+				if err := g.Generate(1); err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+				results = append(results, g.GetResults()...)
+			}
+			if len(results) != 2 || results[0].PrivateKey+results[0].Salt != results[1].PrivateKey+results[1].Salt {
+				t.Errorf("results with the same source differ: %+v", results)
+			}
+		})
+	}
+}
+
+func TestEntropySourceFailure(t *testing.T) {
+	g := NewGenerator("a", "any", false, 1, false, "")
+	g.SetEntropySource(bytes.NewReader(nil))
+	if err := g.Generate(2); err == nil {
+		t.Error("Generate() succeeded with a failing entropy source")
+	}
+}
+
+func TestUserEntropyEd25519(t *testing.T) {
+	random := sequence(32)
+	g := NewGenerator("test", "end", false, 1, false, "")
+	g.SetNode()
+	g.SetEntropySource(bytes.NewReader(random))
+	g.SetUserEntropy([]byte("316425"))
+
+	privKey, err := g.newEd25519Key()
+	if err != nil {
+		t.Fatalf("newEd25519Key() error = %v", err)
+	}
+	digest := sha256.Sum256([]byte("316425"))
+	seed := sha256.Sum256(append(digest[:], random...))
+	if !bytes.Equal(privKey[:32], seed[:]) {
+		t.Errorf("seed = %x, want %x", privKey[:32], seed)
+	}
+}
@@ -0,0 +1,139 @@
+package vanity
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"golang.org/x/crypto/sha3"
+)
+
+// ParseEVMAddress parses a 20-byte address written as 0x hex or as init1
+// bech32
+func ParseEVMAddress(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		raw, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex address '%s': %v", s, err)
+		}
+		if len(raw) != 20 {
+			return nil, fmt.Errorf("address '%s' is %d bytes, expected 20", s, len(raw))
+		}
+		return raw, nil
+	}
+
+	hrp, raw, err := bech32.DecodeAndConvert(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bech32 address '%s': %v", s, err)
+	}
+	if hrp != AccountHRP {
+		return nil, fmt.Errorf("address '%s' has prefix '%s', expected 'init'", s, hrp)
+	}
+	if len(raw) != 20 {
+		return nil, fmt.Errorf("address '%s' is %d bytes, expected 20", s, len(raw))
+	}
+	return raw, nil
+}
+
+// ParseHash parses a 32-byte hash written in hex, with or without 0x
+func ParseHash(s string) ([]byte, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hash '%s': %v", s, err)
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("hash '%s' is %d bytes, expected 32", s, len(raw))
+	}
+	return raw, nil
+}
+
+// keccak256 returns the Keccak-256 hash used by the EVM
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// ChecksumAddress formats a 20-byte address as 0x hex with the EIP-55
+// mixed-case checksum
+func ChecksumAddress(addr []byte) string {
+	lower := hex.EncodeToString(addr)
+	hash := keccak256([]byte(lower))
+
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// Create2Address returns the address of a contract deployed with CREATE2,
+// i.e. the last 20 bytes of keccak256(0xff ++ deployer ++ salt ++ initCodeHash)
+func Create2Address(deployer, salt, initCodeHash []byte) []byte {
+	return keccak256([]byte{0xff}, deployer, salt, initCodeHash)[12:]
+}
+
+// create2Source draws random salts and derives the contract address each
+// would give a CREATE2 deployment. No keys are involved.
+type create2Source struct {
+	g            *Generator
+	deployer     []byte
+	display      string
+	initCodeHash []byte
+	encoding     string
+}
+
+func (s create2Source) next(yield yieldFunc) error {
+	salt, err := s.g.entropy(32)
+	if err != nil {
+		return err
+	}
+
+	addr := Create2Address(s.deployer, salt, s.initCodeHash)
+	address, err := encodeAddress(addr, s.encoding)
+	if err != nil {
+		return err
+	}
+	yield(candidate{address: address, fill: func(r *Result) {
+		r.Salt = "0x" + hex.EncodeToString(salt)
+		r.Deployer = s.display
+		r.HexAddress = ChecksumAddress(addr)
+		r.Bech32Address, _ = encodeAddress(addr, EncodingBech32)
+	}})
+	return nil
+}
+
+func (s create2Source) alphabet() string {
+	return encodingAlphabet(s.encoding)
+}
+
+func (s create2Source) dataLen() int {
+	return encodedLen(20, s.encoding)
+}
+
+// SetCreate2 switches the generator from keys to CREATE2 salts: candidates
+// are random 32-byte salts, matched on the contract address that deployer
+// would create from them for the given init code hash. Hex addresses are
+// matched in lowercase; results carry the salt and the checksummed and
+// bech32 forms of the address. It must be called before Generate.
+func (g *Generator) SetCreate2(deployer, initCodeHash, encoding string) error {
+	addr, err := ParseEVMAddress(deployer)
+	if err != nil {
+		return err
+	}
+	hash, err := ParseHash(initCodeHash)
+	if err != nil {
+		return err
+	}
+	g.setSource(create2Source{g: g, deployer: addr, display: deployer, initCodeHash: hash, encoding: encoding})
+	return nil
+}
@@ -0,0 +1,128 @@
+package vanity
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestParseEVMAddress(t *testing.T) {
+	want := make([]byte, 20)
+	for i := range want {
+		want[i] = byte(i + 1)
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{"hex", "0x0102030405060708090a0b0c0d0e0f1011121314", false},
+		{"checksummed hex", "0x0102030405060708090A0B0C0D0E0F1011121314", false},
+		{"bech32", "init1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc535vdd7", false},
+		{"short hex", "0x0102", true},
+		{"wrong prefix", "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", true},
+		{"not hex", "0xzz02030405060708090a0b0c0d0e0f1011121314", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEVMAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEVMAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(got, want) {
+				t.Errorf("ParseEVMAddress() = %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	tests := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+
+	for _, want := range tests {
+		raw, _ := hex.DecodeString(strings.ToLower(want[2:]))
+		if got := ChecksumAddress(raw); got != want {
+			t.Errorf("ChecksumAddress() = %s, want %s", got, want)
+		}
+	}
+}
+
+// Examples from EIP-1014
+func TestCreate2Address(t *testing.T) {
+	tests := []struct {
+		deployer string
+		salt     string
+		initCode string
+		want     string
+	}{
+		{
+			deployer: "0x0000000000000000000000000000000000000000",
+			salt:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+			initCode: "00",
+			want:     "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38",
+		},
+		{
+			deployer: "0xdeadbeef00000000000000000000000000000000",
+			salt:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+			initCode: "00",
+			want:     "0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3",
+		},
+		{
+			deployer: "0x00000000000000000000000000000000deadbeef",
+			salt:     "0x00000000000000000000000000000000000000000000000000000000cafebabe",
+			initCode: "deadbeef",
+			want:     "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7",
+		},
+	}
+
+	for _, tt := range tests {
+		deployer, _ := ParseEVMAddress(tt.deployer)
+		salt, _ := ParseHash(tt.salt)
+		initCode, _ := hex.DecodeString(tt.initCode)
+
+		got := ChecksumAddress(Create2Address(deployer, salt, keccak256(initCode)))
+		if got != tt.want {
+			t.Errorf("Create2Address(%s, %s) = %s, want %s", tt.deployer, tt.salt, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateCreate2(t *testing.T) {
+	initCodeHash := "0x" + hex.EncodeToString(keccak256([]byte{0x00}))
+
+	g := NewGenerator("00", "start", false, 1, false, "")
+	if err := g.SetCreate2("0x00000000000000000000000000000000deadbeef", initCodeHash, EncodingHex); err != nil {
+		t.Fatalf("SetCreate2() error = %v", err)
+	}
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	results := g.GetResults()
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	result := results[0]
+	if !strings.HasPrefix(result.Address, "0x00") {
+		t.Errorf("address does not start with 00: %s", result.Address)
+	}
+
+	deployer, _ := ParseEVMAddress(result.Deployer)
+	salt, _ := ParseHash(result.Salt)
+	hash, _ := ParseHash(initCodeHash)
+	addr := Create2Address(deployer, salt, hash)
+	if result.HexAddress != ChecksumAddress(addr) || !strings.EqualFold(result.Address, result.HexAddress) {
+		t.Errorf("hex address %s does not match salt %s", result.HexAddress, result.Salt)
+	}
+	if want, _ := encodeAddress(addr, EncodingBech32); result.Bech32Address != want {
+		t.Errorf("bech32 address = %s, want %s", result.Bech32Address, want)
+	}
+}
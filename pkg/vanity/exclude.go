@@ -0,0 +1,49 @@
+package vanity
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// SetExclusions rejects otherwise matching addresses that contain any of the
+// given substrings anywhere after the separator, e.g. profanity or competitor
+// names. Rejections are counted in Stats.Rejected. It must be called before
+// Generate.
+func (g *Generator) SetExclusions(substrings []string) {
+	var values []string
+	for _, s := range substrings {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s != "" {
+			values = append(values, s)
+		}
+	}
+
+	g.excluder = nil
+	if len(values) > 0 {
+		g.excluder = newMatcher(values)
+	}
+}
+
+// isExcluded reports whether an address contains an excluded substring
+func (g *Generator) isExcluded(address string) bool {
+	if g.excluder == nil {
+		return false
+	}
+
+	excluded := false
+	g.excluder.scan(dataPart(strings.ToLower(address)), func(int, int) bool {
+		excluded = true
+		return false
+	})
+	return excluded
+}
+
+// reject checks a matching address against the exclusions and counts it in
+// the statistics when it is rejected
+func (g *Generator) reject(address string) bool {
+	if !g.isExcluded(address) {
+		return false
+	}
+	atomic.AddUint64(&g.stats.Rejected, 1)
+	return true
+}
@@ -0,0 +1,48 @@
+package vanity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsExcluded(t *testing.T) {
+	g := NewGenerator("q", "start", false, 1, false, "")
+	g.SetExclusions([]string{"Dum", " scam ", ""})
+
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{address: "init1qqdumqq", want: true},
+		{address: "init1qqqscam", want: true},
+		{address: "init1qqqqqqq", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if got := g.isExcluded(tt.address); got != tt.want {
+				t.Errorf("isExcluded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateWithExclusions(t *testing.T) {
+	// Reject most addresses so that rejections are all but certain
+	excluded := []string{"q", "z", "r", "y"}
+	g := NewGenerator("p", "start", false, 2, false, "")
+	g.SetExclusions(excluded)
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, result := range g.GetResults() {
+		if strings.ContainsAny(dataPart(result.Address), strings.Join(excluded, "")) {
+			t.Errorf("excluded substring in address: %s", result.Address)
+		}
+	}
+	if g.GetStats().Rejected == 0 {
+		t.Error("expected some rejected candidates")
+	}
+}
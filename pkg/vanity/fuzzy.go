@@ -0,0 +1,237 @@
+package vanity
+
+import (
+	"math"
+	"slices"
+)
+
+// Distance metrics for fuzzy matching
+const (
+	MetricHamming     = "hamming"
+	MetricLevenshtein = "levenshtein"
+)
+
+// SetMaxDistance lets patterns match when the address window at the
+// pattern's position is within maxDistance edits of the pattern, measured
+// with the given metric. Results record their distance and are ranked by it.
+// Pattern classes are still matched exactly. It must be called before
+// Generate.
+func (g *Generator) SetMaxDistance(maxDistance int, metric string) {
+	g.maxDistance = maxDistance
+	g.metric = metric
+}
+
+// matchFuzzy returns a hit for every pattern within the maximum distance of
+// the address data
+func (g *Generator) matchFuzzy(data string, prefixLen int) []hit {
+	var hits []hit
+	for idx, p := range g.patterns {
+		w, ok := g.windows[idx].(wildcard)
+		if !ok {
+			if start, ok := findWindow(g.windows[idx], p.Position, data); ok {
+				hits = append(hits, hit{pattern: idx, offset: prefixLen + start})
+			}
+			continue
+		}
+
+		var distance, start int
+		if g.metric == MetricLevenshtein {
+			distance, start = levenshteinAt(w, p.Position, data)
+		} else {
+			distance, start = hammingAt(w, p.Position, data)
+		}
+		if distance <= g.maxDistance {
+			hits = append(hits, hit{pattern: idx, offset: prefixLen + start, distance: distance})
+		}
+	}
+	return hits
+}
+
+// hamming counts the positions at which w doesn't match data at start
+func hamming(w wildcard, data string, start int) int {
+	d := 0
+	for i := range w {
+		if !w[i][data[start+i]] {
+			d++
+		}
+	}
+	return d
+}
+
+// hammingAt returns the smallest Hamming distance between w and a window of
+// data at the given position, and the offset of that window
+func hammingAt(w wildcard, position string, data string) (int, int) {
+	if len(w) > len(data) {
+		return math.MaxInt, 0
+	}
+	switch position {
+	case "start":
+		return hamming(w, data, 0), 0
+	case "end":
+		start := len(data) - len(w)
+		return hamming(w, data, start), start
+	default:
+		best, bestStart := math.MaxInt, 0
+		for start := 0; start+len(w) <= len(data); start++ {
+			if d := hamming(w, data, start); d < best {
+				best, bestStart = d, start
+			}
+		}
+		return best, bestStart
+	}
+}
+
+// levenshteinAt returns the smallest Levenshtein distance between w and a
+// substring of data at the given position, and the offset of that substring
+func levenshteinAt(w wildcard, position string, data string) (int, int) {
+	switch position {
+	case "start":
+		d, _, _ := levenshtein(w, data, false)
+		return d, 0
+	case "end":
+		reversed := slices.Clone(w)
+		slices.Reverse(reversed)
+		rdata := []byte(data)
+		slices.Reverse(rdata)
+		d, _, end := levenshtein(reversed, string(rdata), false)
+		return d, len(data) - end
+	default:
+		d, start, _ := levenshtein(w, data, true)
+		return d, start
+	}
+}
+
+// levenshtein computes the edit distance between w and the best-matching
+// prefix of data. With anywhere set the match may start at any offset
+// (Sellers' algorithm). It returns the distance and where the best match
+// starts and ends in data.
+func levenshtein(w wildcard, data string, anywhere bool) (int, int, int) {
+	n := len(data) + 1
+	prev, curr := make([]int, n), make([]int, n)
+	prevStart, currStart := make([]int, n), make([]int, n)
+	for j := range prev {
+		if anywhere {
+			prevStart[j] = j
+		} else {
+			prev[j] = j
+		}
+	}
+
+	for i := range w {
+		curr[0], currStart[0] = i+1, prevStart[0]
+		for j := 1; j < n; j++ {
+			cost := 1
+			if w[i][data[j-1]] {
+				cost = 0
+			}
+			curr[j], currStart[j] = prev[j-1]+cost, prevStart[j-1]
+			if prev[j]+1 < curr[j] {
+				curr[j], currStart[j] = prev[j]+1, prevStart[j]
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j], currStart[j] = curr[j-1]+1, currStart[j-1]
+			}
+		}
+		prev, curr = curr, prev
+		prevStart, currStart = currStart, prevStart
+	}
+
+	best, bestEnd := prev[0], 0
+	for j := 1; j < n; j++ {
+		if prev[j] < best {
+			best, bestEnd = prev[j], j
+		}
+	}
+	return best, prevStart[bestEnd], bestEnd
+}
+
+// fuzzyProbability returns the chance that a random window is within
+// maxDistance mismatches of w, i.e. substitutions only as in the Hamming
+// metric. See levenshteinProbability for the Levenshtein metric.
+func fuzzyProbability(w wildcard, maxDistance int, alphabet string) float64 {
+	// dist[k] is the probability of exactly k mismatches so far
+	dist := make([]float64, len(w)+1)
+	dist[0] = 1
+	for i := range w {
+		q := wildcard{w[i]}.probability(alphabet)
+		for k := i + 1; k > 0; k-- {
+			dist[k] = dist[k]*q + dist[k-1]*(1-q)
+		}
+		dist[0] *= q
+	}
+
+	p := 0.0
+	for k := 0; k <= maxDistance && k < len(dist); k++ {
+		p += dist[k]
+	}
+	return p
+}
+
+// levenshteinProbability returns the chance that a random address with
+// dataLen data characters from alphabet is within maxDistance edits of w at
+// the given position. It runs the levenshtein dynamic program over random
+// characters, tracking the probability of every possible column with
+// distances capped at maxDistance+1, so insertions and deletions are counted
+// exactly. A match at any offset is counted once for the whole address.
+func levenshteinProbability(w wildcard, maxDistance int, position string, alphabet string, dataLen int) float64 {
+	if len(w) <= maxDistance {
+		return 1
+	}
+	if position == "end" {
+		// Characters are independent, so a suffix match is a prefix match
+		// of the reversed pattern
+		w = slices.Clone(w)
+		slices.Reverse(w)
+	}
+	anywhere := position == "any"
+	limit := byte(maxDistance + 1)
+
+	column := make([]byte, len(w)+1)
+	for i := range column {
+		column[i] = byte(min(i, maxDistance+1))
+	}
+	states := map[string]float64{string(column): 1}
+	matched := 0.0
+	q := 1 / float64(len(alphabet))
+
+	next := make([]byte, len(w)+1)
+	for j := 0; j < dataLen && len(states) > 0; j++ {
+		following := make(map[string]float64, len(states))
+		for state, p := range states {
+			for c := 0; c < len(alphabet); c++ {
+				next[0] = min(state[0]+1, limit)
+				if anywhere {
+					next[0] = 0
+				}
+				alive := next[0] < limit
+				for i := 1; i <= len(w); i++ {
+					cost := byte(1)
+					if w[i-1][alphabet[c]] {
+						cost = 0
+					}
+					next[i] = min(state[i-1]+cost, state[i]+1, next[i-1]+1, limit)
+					alive = alive || next[i] < limit
+				}
+				switch {
+				case next[len(w)] <= byte(maxDistance):
+					matched += p * q
+				case alive:
+					following[string(next)] += p * q
+				}
+			}
+		}
+		states = following
+	}
+	return matched
+}
+
+// fuzzyWindow wraps a wildcard so that its probability accounts for the
+// allowed distance
+type fuzzyWindow struct {
+	wildcard
+	maxDistance int
+}
+
+func (f fuzzyWindow) probability(alphabet string) float64 {
+	return fuzzyProbability(f.wildcard, f.maxDistance, alphabet)
+}
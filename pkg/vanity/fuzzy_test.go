@@ -0,0 +1,155 @@
+package vanity
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHammingAt(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		position string
+		data     string
+		want     int
+	}{
+		{pattern: "dewmud", position: "start", data: "dewmudqqqq", want: 0},
+		{pattern: "dewmud", position: "start", data: "dxwmuxqqqq", want: 2},
+		{pattern: "dewmud", position: "end", data: "qqqqdewmux", want: 1},
+		{pattern: "dewmud", position: "any", data: "qqdxwmudqq", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.data, func(t *testing.T) {
+			w, _ := compileWildcard(tt.pattern, Bech32Charset)
+			if got, _ := hammingAt(w, tt.position, tt.data); got != tt.want {
+				t.Errorf("hammingAt() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevenshteinAt(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		position  string
+		data      string
+		want      int
+		wantStart int
+	}{
+		{pattern: "dewmud", position: "start", data: "dewmudqqqq", want: 0, wantStart: 0},
+		{pattern: "dewmud", position: "start", data: "dwmudqqqqq", want: 1, wantStart: 0},
+		{pattern: "dewmud", position: "start", data: "dexwmudqqq", want: 1, wantStart: 0},
+		{pattern: "dewmud", position: "end", data: "qqqqdewmd", want: 1, wantStart: 4},
+		{pattern: "dewmud", position: "any", data: "qqdewxmudqq", want: 1, wantStart: 2},
+		{pattern: "dewmud", position: "any", data: "qqqqqqqqqq", want: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.data, func(t *testing.T) {
+			w, _ := compileWildcard(tt.pattern, Bech32Charset)
+			got, start := levenshteinAt(w, tt.position, tt.data)
+			if got != tt.want {
+				t.Errorf("levenshteinAt() distance = %d, want %d", got, tt.want)
+			}
+			if got < len(tt.pattern) && start != tt.wantStart {
+				t.Errorf("levenshteinAt() start = %d, want %d", start, tt.wantStart)
+			}
+		})
+	}
+}
+
+func TestFuzzyDifficulty(t *testing.T) {
+	exact := NewGenerator("dewmud", "start", false, 1, false, "")
+	fuzzy := NewGenerator("dewmud", "start", false, 1, false, "")
+	fuzzy.SetMaxDistance(1, MetricHamming)
+
+	// One substitution allowed: 1 + 6*31 matching spellings out of 32^6
+	want := math.Pow(32, 6) / (1 + 6*31)
+	if got := fuzzy.Difficulty(); math.Abs(got-want) > 1e-6*want {
+		t.Errorf("Difficulty() = %v, want %v", got, want)
+	}
+	if fuzzy.Difficulty() >= exact.Difficulty() {
+		t.Error("fuzzy matching should be easier than exact matching")
+	}
+
+	// Insertions and deletions match about as often as substitutions: 1 in
+	// 4705 rather than the 1 in 8389 that substitutions alone give
+	edits := NewGenerator("dewm", "start", false, 1, false, "")
+	edits.SetMaxDistance(1, MetricLevenshtein)
+	if got := edits.Difficulty(); got < 4700 || got > 4710 {
+		t.Errorf("Difficulty() = %v, want about 4705", got)
+	}
+}
+
+func TestGenerateFuzzy(t *testing.T) {
+	g := NewGenerator("qpz", "end", false, 3, false, "")
+	g.SetMaxDistance(1, MetricLevenshtein)
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	results := g.GetResults()
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i, result := range results {
+		if result.Distance > 1 {
+			t.Errorf("distance %d exceeds the maximum", result.Distance)
+		}
+		if i > 0 && result.Distance < results[i-1].Distance {
+			t.Error("results are not ranked by distance")
+		}
+	}
+}
+
+func TestLevenshteinProbability(t *testing.T) {
+	// Every address over a small alphabet, checked with levenshteinAt
+	const alphabet, dataLen = "abc", 7
+	var addresses []string
+	var build func(prefix string)
+	build = func(prefix string) {
+		if len(prefix) == dataLen {
+			addresses = append(addresses, prefix)
+			return
+		}
+		for _, c := range alphabet {
+			build(prefix + string(c))
+		}
+	}
+	build("")
+
+	tests := []struct {
+		pattern     string
+		position    string
+		maxDistance int
+	}{
+		{"abca", "start", 1},
+		{"abca", "end", 1},
+		{"abca", "any", 1},
+		{"ab?cab", "start", 2},
+		{"aabc", "end", 2},
+		{"cabba", "any", 2},
+		{"ab", "any", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.position, func(t *testing.T) {
+			w, err := compileWildcard(tt.pattern, alphabet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			matches := 0
+			for _, data := range addresses {
+				if d, _ := levenshteinAt(w, tt.position, data); d <= tt.maxDistance {
+					matches++
+				}
+			}
+			want := float64(matches) / float64(len(addresses))
+			got := levenshteinProbability(w, tt.maxDistance, tt.position, alphabet, dataLen)
+			if math.Abs(got-want) > 1e-9 {
+				t.Errorf("levenshteinProbability() = %v, want %v", got, want)
+			}
+		})
+	}
+}
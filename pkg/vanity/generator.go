@@ -5,6 +5,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -14,64 +17,203 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
-	"github.com/cosmos/go-bip39"
 )
 
 // Result represents a generated vanity address and its keys
 type Result struct {
-	Address        string `json:"address"`
-	PrivateKey     string `json:"private_key"`
-	PublicKey      string `json:"public_key"`
-	Mnemonic       string `json:"mnemonic,omitempty"`
-	DerivationPath string `json:"derivation_path,omitempty"`
+	Address        string  `json:"address"`
+	PrivateKey     string  `json:"private_key,omitempty"`
+	PublicKey      string  `json:"public_key,omitempty"`
+	Mnemonic       string  `json:"mnemonic,omitempty"`
+	Language       string  `json:"language,omitempty"`
+	DerivationPath string  `json:"derivation_path,omitempty"`
+	Pattern        string  `json:"pattern,omitempty"`
+	Word           string  `json:"word,omitempty"`
+	Offset         int     `json:"offset,omitempty"`
+	Variant        string  `json:"variant,omitempty"`
+	Distance       int     `json:"distance,omitempty"`
+	Score          int     `json:"score,omitempty"`
+	Seed           string  `json:"seed,omitempty"`
+	Creator        string  `json:"creator,omitempty"`
+	Salt           string  `json:"salt,omitempty"`
+	Deployer       string  `json:"deployer,omitempty"`
+	Nonce          *uint64 `json:"nonce,omitempty"`
+	HexAddress     string  `json:"hex_address,omitempty"`
+	Bech32Address  string  `json:"bech32_address,omitempty"`
+	AccountAddress string  `json:"account_address,omitempty"`
+	MultisigPubKey string  `json:"multisig_pub_key,omitempty"`
+	Index          *uint32 `json:"index,omitempty"`
+
+	// Key file for the node software, e.g. priv_validator_key.json, and its
+	// contents
+	KeyFileName string          `json:"key_file_name,omitempty"`
+	KeyFile     json.RawMessage `json:"key_file,omitempty"`
+
+	// Search metadata: the attempt that found the address, seconds since the
+	// search started, the worker that found it (numbered from 1) and the
+	// attempts used so far divided by the number expected for this many
+	// results. A luck below 1 means the result came sooner than expected.
+	Attempt uint64  `json:"attempt,omitempty"`
+	Elapsed float64 `json:"elapsed_seconds,omitempty"`
+	Worker  int     `json:"worker,omitempty"`
+	Luck    float64 `json:"luck,omitempty"`
 }
 
-// Stats holds generation statistics
+// Stats holds generation statistics. BestPartial is the longest partial match
+// seen, in characters, and Histogram[n] counts the attempts whose longest
+// partial match was n characters long. StartTime and EndTime are Unix
+// nanosecond timestamps; EndTime stays zero while the search is running.
 type Stats struct {
-	Attempts  uint64
-	Found     uint64
-	StartTime int64
-	EndTime   int64
+	Attempts           uint64   `json:"attempts"`
+	Found              uint64   `json:"found"`
+	Rejected           uint64   `json:"rejected"`
+	BestPartial        int      `json:"best_partial"`
+	BestPartialAddress string   `json:"best_partial_address,omitempty"`
+	Histogram          []uint64 `json:"histogram,omitempty"`
+	StartTime          int64    `json:"start_time_ns"`
+	EndTime            int64    `json:"end_time_ns,omitempty"`
 }
 
 // Generator handles the vanity address generation
 type Generator struct {
-	pattern       string
-	position      string
+	patterns      []Pattern
+	windows       []window
+	matcher       *matcher
+	literals      []int
+	partials      partials
+	excluder      *matcher
 	caseSensitive bool
 	count         int
+	found         []int
+	bases         []Pattern
+	variantOf     []int
+	dictionary    bool
+	constraints   bool
+	requireAll    bool
+	maxDistance   int
+	metric        string
+	scoring       string
+	maxScore      int
+	target        int
+	threshold     atomic.Int64
+	boardVersion  atomic.Uint64
+	nearMiss      atomic.Int64
 	useMnemonic   bool
 	mnemonic      string
+	words         int
+	language      string
+	userEntropy   []byte
+	rand          io.Reader
+	health        healthMonitor
+	src           source
+	expected      float64
+	startTime     time.Time
 	stats         *Stats
 	results       []Result
 	stopCh        chan struct{}
 	progressCh    chan struct{}
 	stopped       atomic.Bool
+	err           error
 	mu            sync.Mutex
 }
 
 // NewGenerator creates a new vanity address generator
 func NewGenerator(pattern, position string, caseSensitive bool, count int, useMnemonic bool, mnemonic string) *Generator {
-	return &Generator{
-		pattern:       pattern,
-		position:      position,
+	patterns := []Pattern{{Value: pattern, Position: position, Count: count}}
+	return NewMultiGenerator(patterns, caseSensitive, useMnemonic, mnemonic)
+}
+
+// NewMultiGenerator creates a generator that searches for several patterns at
+// once. Every candidate address is checked against all literal patterns in a
+// single pass, and generation stops once each pattern has reached its own
+// count. Patterns may use wildcard syntax or name a pattern class; malformed
+// patterns never match.
+func NewMultiGenerator(patterns []Pattern, caseSensitive bool, useMnemonic bool, mnemonic string) *Generator {
+	windows := compileWindows(patterns, caseSensitive, Bech32Charset)
+	var values []string
+	var literals []int
+	count, longest := 0, 0
+	for i, p := range patterns {
+		value := p.Value
+		if !caseSensitive {
+			value = strings.ToLower(value)
+		}
+		longest = max(longest, windows[i].size())
+		if isLiteral(value) {
+			values = append(values, value)
+			literals = append(literals, i)
+		}
+		count += p.Count
+	}
+
+	g := &Generator{
+		patterns:      append([]Pattern{}, patterns...),
+		windows:       windows,
+		matcher:       newMatcher(values),
+		literals:      literals,
+		partials:      compilePartials(patterns, caseSensitive, Bech32Charset),
 		caseSensitive: caseSensitive,
 		count:         count,
+		found:         make([]int, len(patterns)),
 		useMnemonic:   useMnemonic,
 		mnemonic:      mnemonic,
-		stats:         &Stats{},
+		stats:         &Stats{Histogram: make([]uint64, longest+1)},
 		stopCh:        make(chan struct{}),
 	}
+	g.src = keySource{g: g, hrp: AccountHRP}
+	return g
+}
+
+// compileWindows compiles pattern values for addresses written in alphabet
+func compileWindows(patterns []Pattern, caseSensitive bool, alphabet string) []window {
+	windows := make([]window, len(patterns))
+	for i, p := range patterns {
+		value := p.Value
+		if !caseSensitive {
+			value = strings.ToLower(value)
+		}
+		w, err := compileWindow(value, alphabet)
+		if err != nil {
+			w = wildcard{charClass{}}
+		}
+		windows[i] = w
+	}
+	return windows
+}
+
+// setSource replaces the source of candidate addresses and recompiles the
+// patterns for its alphabet
+func (g *Generator) setSource(src source) {
+	g.src = src
+	g.windows = compileWindows(g.patterns, g.caseSensitive, src.alphabet())
+	g.partials = compilePartials(g.patterns, g.caseSensitive, src.alphabet())
 }
 
-// generateMnemonic generates a new random mnemonic
+// Alphabet returns the characters that the searched addresses are written in
+func (g *Generator) Alphabet() string {
+	return g.src.alphabet()
+}
+
+// generateMnemonic generates a new random mnemonic of the chosen length
 func (g *Generator) generateMnemonic() (string, error) {
-	entropy, err := bip39.NewEntropy(256)
+	words := g.words
+	if words == 0 {
+		words = DefaultWords
+	}
+	bits, err := EntropyBits(words)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate entropy: %v", err)
+		return "", err
+	}
+	entropy, err := g.entropy(bits / 8)
+	if err != nil {
+		return "", err
+	}
+	// The entropy determines every key derived from the mnemonic
+	if err := g.health.check(entropy); err != nil {
+		return "", err
 	}
 
-	mnemonic, err := bip39.NewMnemonic(entropy)
+	mnemonic, err := NewMnemonic(entropy, g.mnemonicLanguage())
 	if err != nil {
 		return "", fmt.Errorf("failed to generate mnemonic: %v", err)
 	}
@@ -81,15 +223,18 @@ func (g *Generator) generateMnemonic() (string, error) {
 
 // generateAddress creates a new random Cosmos SDK compatible address
 func (g *Generator) generateAddress() (string, string, string, error) {
-	// Generate private key using Cosmos SDK's secp256k1
-	privKey := secp256k1.GenPrivKey()
+	// Draw a secp256k1 key from the entropy source, mixed with any user entropy
+	privKey, err := g.newPrivKey()
+	if err != nil {
+		return "", "", "", err
+	}
 	pubKey := privKey.PubKey()
 
 	// Get address from public key
 	addr := sdk.AccAddress(pubKey.Address())
 
 	// Convert to bech32 with "init" prefix
-	address, err := bech32.ConvertAndEncode("init", addr)
+	address, err := bech32.ConvertAndEncode(AccountHRP, addr)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -115,8 +260,8 @@ func (g *Generator) generateAddressFromMnemonic() (string, string, string, strin
 	var mnemonic string
 	if g.mnemonic != "" {
 		// Validate provided mnemonic
-		if !bip39.IsMnemonicValid(g.mnemonic) {
-			return "", "", "", "", "", fmt.Errorf("invalid mnemonic provided")
+		if err := g.checkMnemonic(g.mnemonic); err != nil {
+			return "", "", "", "", "", err
 		}
 		mnemonic = g.mnemonic
 	} else {
@@ -128,7 +273,7 @@ func (g *Generator) generateAddressFromMnemonic() (string, string, string, strin
 	}
 
 	// Derive seed from mnemonic
-	seed := bip39.NewSeed(mnemonic, "")
+	seed := MnemonicSeed(mnemonic, "")
 
 	// Create master key and derive path
 	master, ch := hd.ComputeMastersFromSeed(seed)
@@ -154,7 +299,7 @@ func (g *Generator) generateAddressFromMnemonic() (string, string, string, strin
 	addr := sdk.AccAddress(pubKey.Address())
 
 	// Convert to bech32 with "init" prefix
-	address, err := bech32.ConvertAndEncode("init", addr)
+	address, err := bech32.ConvertAndEncode(AccountHRP, addr)
 	if err != nil {
 		return "", "", "", "", "", err
 	}
@@ -173,38 +318,201 @@ func (g *Generator) generateAddressFromMnemonic() (string, string, string, strin
 	return address, privKeyHex, string(pubKeyBytes), mnemonic, path, nil
 }
 
-// isMatch checks if an address matches the pattern
+// hit is a pattern occurrence in an address
+type hit struct {
+	pattern  int // index into Generator.patterns
+	offset   int // byte offset of the occurrence within the address
+	distance int // edit distance for fuzzy matches
+}
+
+// isMatch checks if an address matches any of the patterns
 func (g *Generator) isMatch(address string) bool {
-	pattern := g.pattern
+	return len(g.matchPatterns(address)) > 0
+}
+
+// matchPatterns returns the first occurrence of every pattern that the
+// address matches, in pattern order
+func (g *Generator) matchPatterns(address string) []hit {
 	if !g.caseSensitive {
-		pattern = strings.ToLower(pattern)
 		address = strings.ToLower(address)
 	}
+	data := dataPart(address)
+	prefixLen := len(address) - len(data)
+
+	if g.metric != "" {
+		return g.matchFuzzy(data, prefixLen)
+	}
+
+	var hits []hit
+	g.matcher.scan(data, func(i, start int) bool {
+		idx := g.literals[i]
+		p := g.patterns[idx]
+		if !matchesAt(p.Position, start, len(p.Value), len(data)) {
+			return true
+		}
+		if !slices.ContainsFunc(hits, func(h hit) bool { return h.pattern == idx }) {
+			hits = append(hits, hit{pattern: idx, offset: prefixLen + start})
+		}
+		return true
+	})
+
+	// Wildcards and pattern classes can't go through the automaton and are
+	// tested one by one
+	if len(g.literals) < len(g.patterns) {
+		for idx, p := range g.patterns {
+			if isLiteral(p.Value) {
+				continue
+			}
+			if start, ok := findWindow(g.windows[idx], p.Position, data); ok {
+				hits = append(hits, hit{pattern: idx, offset: prefixLen + start})
+			}
+		}
+	}
+
+	slices.SortFunc(hits, func(a, b hit) int { return a.pattern - b.pattern })
+	return hits
+}
+
+// Difficulty returns the expected number of attempts needed to find one
+// matching address. It returns +Inf when no pattern can ever match, for
+// example because it contains characters outside the bech32 alphabet.
+func (g *Generator) Difficulty() float64 {
+	if g.requireAll {
+		p := 1.0
+		for idx := range g.patterns {
+			p *= g.probability(idx)
+		}
+		return 1 / p
+	}
+
+	p := 0.0
+	for idx := range g.patterns {
+		p += g.probability(idx)
+	}
+	return 1 / math.Min(p, 1)
+}
+
+// probability returns the chance that a random address matches a pattern
+func (g *Generator) probability(idx int) float64 {
+	w := g.windows[idx]
+	if wc, ok := w.(wildcard); ok && g.metric == MetricLevenshtein {
+		return levenshteinProbability(wc, g.maxDistance, g.patterns[idx].Position, g.src.alphabet(), g.src.dataLen())
+	}
+	if wc, ok := w.(wildcard); ok && g.metric != "" {
+		w = fuzzyWindow{wildcard: wc, maxDistance: g.maxDistance}
+	}
+	return positionProbability(w, g.patterns[idx].Position, g.src.alphabet(), g.src.dataLen())
+}
+
+// complete reports whether the search has nothing left to find. The caller
+// must hold g.mu.
+func (g *Generator) complete() bool {
+	if g.scoring != "" {
+		if g.target > 0 && len(g.results) > 0 && g.results[0].Score >= g.target {
+			return true
+		}
+		return g.maxScore > 0 && len(g.results) >= g.count &&
+			g.results[len(g.results)-1].Score >= g.maxScore
+	}
+	return len(g.results) >= g.count
+}
+
+// satisfied reports whether the pattern hits for an address make it a match
+func (g *Generator) satisfied(hits []hit) bool {
+	if g.requireAll {
+		return len(hits) == len(g.patterns)
+	}
+	return len(hits) > 0
+}
+
+// record stores a matching result, crediting the first matched pattern that
+// still needs results
+func (g *Generator) record(result Result, hits []hit) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.results) >= g.count {
+		return
+	}
+
+	result.Luck = g.luck(result.Attempt, len(g.results)+1)
+
+	if g.requireAll {
+		result.Pattern = describeConstraints(g.patterns, " AND ")
+		g.results = append(g.results, result)
+		atomic.AddUint64(&g.stats.Found, 1)
+		return
+	}
+
+	for _, h := range hits {
+		// Lookalike variants share the count of the pattern they came from
+		p, slot := g.patterns[h.pattern], h.pattern
+		if g.variantOf != nil {
+			slot = g.variantOf[h.pattern]
+			p = g.bases[slot]
+		}
+		if p.Count > 0 && g.found[slot] >= p.Count {
+			continue
+		}
+		result.Distance = h.distance
+		switch {
+		case g.dictionary:
+			result.Word = p.Value
+			result.Offset = h.offset
+		case g.constraints:
+			result.Pattern = p.String()
+		case g.variantOf != nil:
+			result.Pattern = p.Value
+			result.Variant = g.patterns[h.pattern].Value
+		default:
+			result.Pattern = p.Value
+		}
+		g.found[slot]++
+		g.results = append(g.results, result)
+		atomic.AddUint64(&g.stats.Found, 1)
+		return
+	}
+}
 
-	switch g.position {
-	case "start":
-		return strings.HasPrefix(address, "init1"+pattern)
-	case "end":
-		return strings.HasSuffix(address, pattern)
-	case "any":
-		return strings.Contains(address, pattern)
-	default:
-		return false
+// luck returns the attempts used to find n results divided by the number
+// expected, or zero when the expectation is unknown
+func (g *Generator) luck(attempts uint64, n int) float64 {
+	if g.expected <= 0 || math.IsInf(g.expected, 0) {
+		return 0
 	}
+	return float64(attempts) / (g.expected * float64(n))
 }
 
-// GetResults returns the generated results
+// GetResults returns the generated results. Fuzzy matches are ranked by
+// distance, closest first.
 func (g *Generator) GetResults() []Result {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	return append([]Result{}, g.results...)
+	results := append([]Result{}, g.results...)
+	if g.metric != "" {
+		slices.SortStableFunc(results, func(a, b Result) int { return a.Distance - b.Distance })
+	}
+	return results
 }
 
 // GetStats returns the current statistics
 func (g *Generator) GetStats() Stats {
+	histogram := make([]uint64, len(g.stats.Histogram))
+	for i := range histogram {
+		histogram[i] = atomic.LoadUint64(&g.stats.Histogram[i])
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	return Stats{
-		Attempts: atomic.LoadUint64(&g.stats.Attempts),
-		Found:    atomic.LoadUint64(&g.stats.Found),
+		Attempts:           atomic.LoadUint64(&g.stats.Attempts),
+		Found:              atomic.LoadUint64(&g.stats.Found),
+		Rejected:           atomic.LoadUint64(&g.stats.Rejected),
+		BestPartial:        g.stats.BestPartial,
+		BestPartialAddress: g.stats.BestPartialAddress,
+		Histogram:          histogram,
+		StartTime:          g.stats.StartTime,
+		EndTime:            g.stats.EndTime,
 	}
 }
 
@@ -215,7 +523,9 @@ func (g *Generator) Stop() {
 	}
 }
 
-func (g *Generator) worker(wg *sync.WaitGroup) {
+// worker generates and checks addresses until the search completes. id
+// identifies the worker in the results it finds.
+func (g *Generator) worker(id int, wg *sync.WaitGroup) {
 	defer wg.Done()
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
@@ -231,7 +541,7 @@ func (g *Generator) worker(wg *sync.WaitGroup) {
 			}
 		default:
 			g.mu.Lock()
-			if len(g.results) >= g.count {
+			if g.complete() {
 				g.mu.Unlock()
 				g.Stop()
 				return
@@ -242,46 +552,63 @@ func (g *Generator) worker(wg *sync.WaitGroup) {
 				return
 			}
 
-			var address, privKey, pubKey, mnemonic, derivationPath string
-			var err error
-
-			if g.useMnemonic {
-				address, privKey, pubKey, mnemonic, derivationPath, err = g.generateAddressFromMnemonic()
-			} else {
-				address, privKey, pubKey, err = g.generateAddress()
-			}
-
+			err := g.src.next(func(c candidate) bool {
+				g.check(id, c)
+				return !g.stopped.Load()
+			})
 			if err != nil {
-				continue
+				g.fail(err)
+				return
 			}
+		}
+	}
+}
 
-			if g.isMatch(address) {
-				result := Result{
-					Address:    address,
-					PrivateKey: privKey,
-					PublicKey:  pubKey,
-				}
-
-				if g.useMnemonic {
-					result.Mnemonic = mnemonic
-					result.DerivationPath = derivationPath
-				}
-
-				g.mu.Lock()
-				if len(g.results) < g.count {
-					g.results = append(g.results, result)
-					atomic.AddUint64(&g.stats.Found, 1)
-				}
-				g.mu.Unlock()
-			}
+// check tests one candidate and keeps it if it matches or ranks on the
+// leaderboard
+func (g *Generator) check(id int, c candidate) {
+	attempt := atomic.AddUint64(&g.stats.Attempts, 1)
+	g.trackNearMiss(c.address)
+
+	newResult := func() Result {
+		result := Result{
+			Address: c.address,
+			Attempt: attempt,
+			Elapsed: time.Since(g.startTime).Seconds(),
+			Worker:  id,
+		}
+		c.fill(&result)
+		return result
+	}
 
-			atomic.AddUint64(&g.stats.Attempts, 1)
+	if g.scoring != "" {
+		if score := g.score(c.address); int64(score) > g.threshold.Load() && !g.reject(c.address) {
+			result := newResult()
+			result.Score = score
+			g.offer(result)
 		}
+		return
+	}
+
+	hits := g.matchPatterns(c.address)
+	if !g.satisfied(hits) {
+		return
+	}
+	if c.also != "" && !g.satisfied(g.matchPatterns(c.also)) {
+		return
+	}
+	if !g.reject(c.address) {
+		g.record(newResult(), hits)
 	}
 }
 
 // Generate starts the address generation process
 func (g *Generator) Generate(threads int) error {
+	// Test the randomness before any key is drawn from it
+	if err := g.startupTest(); err != nil {
+		return err
+	}
+
 	g.progressCh = make(chan struct{}, 1)
 	defer close(g.progressCh)
 
@@ -289,9 +616,16 @@ func (g *Generator) Generate(threads int) error {
 	wg.Add(threads)
 
 	startTime := time.Now()
+	g.startTime = startTime
+	g.stats.StartTime = startTime.UnixNano()
+	if g.scoring == "" {
+		g.expected = g.Difficulty()
+	}
 
 	// Start progress reporter
 	go func() {
+		var shownVersion uint64
+		var shownAt time.Time
 		for range g.progressCh {
 			if g.stopped.Load() {
 				return
@@ -300,6 +634,17 @@ func (g *Generator) Generate(threads int) error {
 			found := atomic.LoadUint64(&g.stats.Found)
 			speed := float64(attempts) / time.Since(startTime).Seconds()
 
+			if g.scoring != "" {
+				// Redraw the leaderboard when it changes, at most once a second
+				if v := g.boardVersion.Load(); v != shownVersion && time.Since(shownAt) >= time.Second {
+					g.printLeaderboard()
+					shownVersion, shownAt = v, time.Now()
+				}
+				fmt.Printf("\rProgress: best score %d | Attempts: %d | Speed: %.2f/s",
+					g.bestScore(), attempts, speed)
+				continue
+			}
+
 			fmt.Printf("\rProgress: %d/%d found | Attempts: %d | Speed: %.2f/s",
 				found, g.count, attempts, speed)
 		}
@@ -307,10 +652,24 @@ func (g *Generator) Generate(threads int) error {
 
 	// Start workers
 	for i := 0; i < threads; i++ {
-		go g.worker(&wg)
+		go g.worker(i+1, &wg)
 	}
 
 	wg.Wait()
+	g.mu.Lock()
+	g.stats.EndTime = time.Now().UnixNano()
+	err := g.err
+	g.mu.Unlock()
 	fmt.Println() // New line after progress
-	return nil
+	return err
+}
+
+// fail stops the search with the first error a worker hits
+func (g *Generator) fail(err error) {
+	g.mu.Lock()
+	if g.err == nil {
+		g.err = err
+	}
+	g.mu.Unlock()
+	g.Stop()
 }
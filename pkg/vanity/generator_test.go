@@ -2,6 +2,7 @@ package vanity
 
 import (
 	"encoding/json"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -15,8 +16,8 @@ func TestNewGenerator(t *testing.T) {
 	if g == nil {
 		t.Fatal("NewGenerator returned nil")
 	}
-	if g.pattern != "test" {
-		t.Errorf("expected pattern 'test', got '%s'", g.pattern)
+	if len(g.patterns) != 1 || g.patterns[0].Value != "test" {
+		t.Errorf("expected pattern 'test', got %v", g.patterns)
 	}
 }
 
@@ -275,6 +276,13 @@ func TestGenerate(t *testing.T) {
 				if len(result.PrivateKey) != 64 {
 					t.Errorf("private key length should be 64 chars, got %d", len(result.PrivateKey))
 				}
+				// Verify search metadata
+				if result.Attempt == 0 || result.Worker < 1 || result.Worker > tt.threads {
+					t.Errorf("invalid search metadata: attempt %d, worker %d", result.Attempt, result.Worker)
+				}
+				if result.Elapsed <= 0 || result.Luck <= 0 {
+					t.Errorf("invalid search metadata: elapsed %v, luck %v", result.Elapsed, result.Luck)
+				}
 
 				// Verify mnemonic-specific fields when using mnemonic
 				if tt.useMnemonic {
@@ -294,14 +302,74 @@ func TestGenerate(t *testing.T) {
 			if stats.Found != uint64(tt.count) {
 				t.Errorf("stats.Found = %d, want %d", stats.Found, tt.count)
 			}
+			if stats.StartTime == 0 || stats.EndTime < stats.StartTime {
+				t.Errorf("invalid timing: start %d, end %d", stats.StartTime, stats.EndTime)
+			}
 		})
 	}
 }
 
+func TestMatchPatterns(t *testing.T) {
+	patterns := []Pattern{
+		{Value: "dao", Position: "start", Count: 1},
+		{Value: "xyz", Position: "end", Count: 1},
+		{Value: "qq", Position: "any", Count: 1},
+	}
+	g := NewMultiGenerator(patterns, false, false, "")
+
+	tests := []struct {
+		address string
+		want    []int
+	}{
+		{address: "init1daoabcxyz", want: []int{0, 1}},
+		{address: "init1abcdaoxyz", want: []int{1}},
+		{address: "init1xyzabcdao", want: nil},
+		{address: "init1aqqaqqa", want: []int{2}},
+		{address: "init1DAOqq", want: []int{0, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			var got []int
+			for _, h := range g.matchPatterns(tt.address) {
+				got = append(got, h.pattern)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("matchPatterns(%s) = %v, want %v", tt.address, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateMultiplePatterns(t *testing.T) {
+	patterns := []Pattern{
+		{Value: "a", Position: "end", Count: 2},
+		{Value: "c", Position: "start", Count: 1},
+	}
+	g := NewMultiGenerator(patterns, false, false, "")
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	perPattern := map[string]int{}
+	for _, result := range g.GetResults() {
+		perPattern[result.Pattern]++
+		if !g.isMatch(result.Address) {
+			t.Errorf("generated address does not match any pattern: %s", result.Address)
+		}
+	}
+	if perPattern["a"] != 2 || perPattern["c"] != 1 {
+		t.Errorf("unexpected results per pattern: %v", perPattern)
+	}
+}
+
 func TestGetStats(t *testing.T) {
 	g := NewGenerator("test", "end", false, 1, false, "")
 	atomic.StoreUint64(&g.stats.Attempts, 100)
 	atomic.StoreUint64(&g.stats.Found, 2)
+	g.stats.StartTime = 10
+	g.stats.EndTime = 20
 
 	stats := g.GetStats()
 	if stats.Attempts != 100 {
@@ -310,6 +378,9 @@ func TestGetStats(t *testing.T) {
 	if stats.Found != 2 {
 		t.Errorf("expected 2 found, got %d", stats.Found)
 	}
+	if stats.StartTime != 10 || stats.EndTime != 20 {
+		t.Errorf("expected start 10 and end 20, got %d and %d", stats.StartTime, stats.EndTime)
+	}
 }
 
 func TestStop(t *testing.T) {
@@ -0,0 +1,112 @@
+package vanity
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Health test parameters after NIST SP 800-90B section 4.4, for bytes
+// assumed to carry at least 2 bits of min-entropy each and a false alarm
+// rate of 2^-20. A working RNG has full entropy, so false alarms are
+// practically impossible.
+const (
+	// RepetitionCutoff is the run of identical bytes that fails the
+	// repetition count test
+	RepetitionCutoff = 11
+	// ProportionWindow is the number of bytes in an adaptive proportion
+	// test window
+	ProportionWindow = 512
+	// ProportionCutoff is the count of the first byte of a window within
+	// that window that fails the adaptive proportion test
+	ProportionCutoff = 177
+	// startupBytes is the number of bytes tested before generation starts
+	startupBytes = 1024
+)
+
+// healthMonitor runs the continuous health tests on the randomness the
+// generator draws. Reads go through it one at a time so the tests see the
+// bytes in order. Once a test fails, every later read fails too.
+type healthMonitor struct {
+	mu sync.Mutex
+	// Repetition count test
+	last byte
+	run  int
+	// Adaptive proportion test
+	first byte
+	count int
+	seen  int
+	// Consecutive outputs
+	prev   []byte
+	failed error
+}
+
+// read fills buf from source and tests the bytes
+func (h *healthMonitor) read(source io.Reader, buf []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.failed != nil {
+		return h.failed
+	}
+	if _, err := io.ReadFull(source, buf); err != nil {
+		return fmt.Errorf("failed to generate entropy: %v", err)
+	}
+	for _, b := range buf {
+		if err := h.sample(b); err != nil {
+			h.failed = fmt.Errorf("entropy source failed health test: %v", err)
+			return h.failed
+		}
+	}
+	return nil
+}
+
+// sample feeds one byte to the repetition count and adaptive proportion
+// tests
+func (h *healthMonitor) sample(b byte) error {
+	if h.run > 0 && b == h.last {
+		h.run++
+		if h.run >= RepetitionCutoff {
+			return fmt.Errorf("repetition count: byte 0x%02x repeated %d times", b, h.run)
+		}
+	} else {
+		h.last, h.run = b, 1
+	}
+
+	if h.seen == 0 {
+		h.first, h.count = b, 0
+	}
+	if b == h.first {
+		h.count++
+		if h.count >= ProportionCutoff {
+			return fmt.Errorf("adaptive proportion: byte 0x%02x seen %d times in %d", b, h.count, ProportionWindow)
+		}
+	}
+	h.seen = (h.seen + 1) % ProportionWindow
+	return nil
+}
+
+// check fails if out, a private key or the entropy of a mnemonic, equals the
+// one drawn before it
+func (h *healthMonitor) check(out []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.failed != nil {
+		return h.failed
+	}
+	if h.prev != nil && bytes.Equal(out, h.prev) {
+		h.failed = fmt.Errorf("entropy source failed health test: two consecutive keys are equal")
+		return h.failed
+	}
+	h.prev = append(h.prev[:0], out...)
+	return nil
+}
+
+// startupTest draws and tests startupBytes bytes before the first key is
+// generated
+func (g *Generator) startupTest() error {
+	buf := make([]byte, startupBytes)
+	return g.health.read(g.entropySource(), buf)
+}
@@ -0,0 +1,140 @@
+package vanity
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHealthMonitor(t *testing.T) {
+	// Bytes 0xaa at every other position, distinct bytes in between
+	proportion := func(n int) []byte {
+		b := make([]byte, 2*n)
+		for i := range b {
+			b[i] = 0xaa
+			if i%2 == 1 {
+				b[i] = byte(i / 2 % 0xaa)
+			}
+		}
+		return b
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"random", sequence(256), ""},
+		{"short run", bytes.Repeat([]byte{0}, RepetitionCutoff-1), ""},
+		{"repetition", bytes.Repeat([]byte{0}, RepetitionCutoff), "repetition count"},
+		{"proportion within cutoff", proportion(ProportionCutoff - 1), ""},
+		{"proportion", proportion(ProportionCutoff), "adaptive proportion"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h healthMonitor
+			err := h.read(bytes.NewReader(tt.data), make([]byte, len(tt.data)))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("read() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("read() error = %v, want %q", err, tt.wantErr)
+			}
+
+			// A failure is permanent
+			if err := h.read(rand.Reader, make([]byte, 32)); err == nil {
+				t.Error("read() succeeded after a failure")
+			}
+		})
+	}
+}
+
+func TestHealthMonitorRandom(t *testing.T) {
+	var h healthMonitor
+	buf := make([]byte, 1<<20)
+	if err := h.read(rand.Reader, buf); err != nil {
+		t.Errorf("read() error = %v", err)
+	}
+}
+
+func TestHealthConsecutiveKeys(t *testing.T) {
+	g := NewGenerator("test", "end", false, 1, false, "")
+	g.SetEntropySource(bytes.NewReader(append(sequence(32), sequence(32)...)))
+
+	if _, err := g.newPrivKey(); err != nil {
+		t.Fatalf("newPrivKey() error = %v", err)
+	}
+	if _, err := g.newPrivKey(); err == nil || !strings.Contains(err.Error(), "consecutive keys") {
+		t.Errorf("newPrivKey() error = %v, want consecutive keys", err)
+	}
+}
+
+// failingReader passes good bytes through and then only zeros
+type failingReader struct {
+	good int
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	for i := range p {
+		if r.good > 0 {
+			r.good--
+			if _, err := io.ReadFull(rand.Reader, p[i:i+1]); err != nil {
+				return i, err
+			}
+		} else {
+			p[i] = 0
+		}
+	}
+	return len(p), nil
+}
+
+// cycleReader repeats the same bytes forever
+type cycleReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *cycleReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.data[r.pos]
+		r.pos = (r.pos + 1) % len(r.data)
+	}
+	return len(p), nil
+}
+
+func TestGenerateHealthFailure(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      io.Reader
+		useMnemonic bool
+		words       int
+	}{
+		{"at startup", &failingReader{}, false, 0},
+		{"while generating", &failingReader{good: 64 * 1024}, false, 0},
+		{"repeated keys", &cycleReader{data: sequence(32)}, false, 0},
+		{"repeated mnemonics", &cycleReader{data: sequence(32)}, true, 0},
+		{"repeated 12-word mnemonics", &cycleReader{data: sequence(32)}, true, 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator("qqqqqqqqqq", "end", false, 1, tt.useMnemonic, "")
+			if tt.words != 0 {
+				if err := g.SetWords(tt.words); err != nil {
+					t.Fatal(err)
+				}
+			}
+			g.SetEntropySource(tt.source)
+			err := g.Generate(2)
+			if err == nil || !strings.Contains(err.Error(), "health test") {
+				t.Errorf("Generate() error = %v, want a health test failure", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package vanity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DropMarker in a character's substitutes lets the character be dropped,
+// e.g. "i=-" turns "alice" into "alce"
+const DropMarker = '-'
+
+// maxVariants caps how many lookalike spellings a single pattern may expand to
+const maxVariants = 1 << 16
+
+// DefaultLookalikes maps characters to the characters that can stand in for
+// them. It covers the letters bech32 leaves out (b, i, o and 1) as well as
+// common leetspeak substitutions.
+var DefaultLookalikes = map[byte]string{
+	'a': "4",
+	'b': "68",
+	'e': "3",
+	'g': "9",
+	'i': "l",
+	'l': "7",
+	'o': "0",
+	's': "5",
+	't': "7",
+	'z': "2",
+	'1': "l",
+}
+
+// ExpandLookalikes returns every spelling of value that only uses bech32
+// characters, substituting characters from the table and dropping those
+// whose substitutes include DropMarker. The value itself is included when it
+// is already valid. An empty result means no spelling is possible.
+func ExpandLookalikes(value string, table map[byte]string) ([]string, error) {
+	value = strings.ToLower(value)
+	variants := []string{""}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		var options []string
+		for _, o := range []byte(string(c) + table[c]) {
+			option := string(o)
+			if o == DropMarker {
+				option = ""
+			} else if !isBech32(option) {
+				continue
+			}
+			if !slices.Contains(options, option) {
+				options = append(options, option)
+			}
+		}
+		if len(options) == 0 {
+			return nil, nil
+		}
+		if len(variants)*len(options) > maxVariants {
+			return nil, fmt.Errorf("pattern '%s' has more than %d lookalike variants", value, maxVariants)
+		}
+
+		next := make([]string, 0, len(variants)*len(options))
+		for _, v := range variants {
+			for _, o := range options {
+				next = append(next, v+o)
+			}
+		}
+		variants = next
+	}
+
+	// Dropped characters can leave nothing, or the same spelling twice
+	seen := make(map[string]bool, len(variants))
+	kept := variants[:0]
+	for _, v := range variants {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			kept = append(kept, v)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, nil
+	}
+	return kept, nil
+}
+
+// NewLookalikeGenerator creates a generator that expands every pattern into
+// its bech32-legal lookalike spellings, e.g. "alice" into "allce", "4llce" and
+// "a7lce", and searches for all of them at once. Each result reports the
+// pattern and the variant that matched. Wildcard and class patterns are
+// searched as given.
+func NewLookalikeGenerator(patterns []Pattern, table map[byte]string, useMnemonic bool, mnemonic string) (*Generator, error) {
+	var expanded []Pattern
+	var variantOf []int
+	for i, p := range patterns {
+		variants := []string{p.Value}
+		if isLiteral(p.Value) {
+			var err error
+			if variants, err = ExpandLookalikes(p.Value, table); err != nil {
+				return nil, err
+			}
+			if len(variants) == 0 {
+				return nil, fmt.Errorf("pattern '%s' has no lookalike spelling in bech32", p.Value)
+			}
+		}
+		for _, v := range variants {
+			expanded = append(expanded, Pattern{Value: v, Position: p.Position})
+			variantOf = append(variantOf, i)
+		}
+	}
+
+	g := NewMultiGenerator(expanded, false, useMnemonic, mnemonic)
+	g.bases = append([]Pattern{}, patterns...)
+	g.variantOf = variantOf
+	g.count = 0
+	for _, p := range patterns {
+		g.count += p.Count
+	}
+	return g, nil
+}
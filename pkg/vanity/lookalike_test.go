@@ -0,0 +1,111 @@
+package vanity
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpandLookalikes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		table map[byte]string
+		want  []string
+	}{
+		{
+			name:  "invalid letter replaced",
+			value: "bob",
+			table: map[byte]string{'b': "6", 'o': "0"},
+			want:  []string{"606"},
+		},
+		{
+			name:  "valid letters keep their spelling",
+			value: "Alice",
+			table: DefaultLookalikes,
+			want:  []string{"allce", "allc3", "a7lce", "a7lc3", "4llce", "4llc3", "47lce", "47lc3"},
+		},
+		{
+			name:  "dropped letter",
+			value: "alice",
+			table: map[byte]string{'i': "-"},
+			want:  []string{"alce"},
+		},
+		{
+			name:  "dropped or replaced letter",
+			value: "alice",
+			table: map[byte]string{'i': "l-", 'e': "3"},
+			want:  []string{"allce", "allc3", "alce", "alc3"},
+		},
+		{
+			name:  "duplicates from drops",
+			value: "aab",
+			table: map[byte]string{'a': "-", 'b': "6"},
+			want:  []string{"aa6", "a6", "6"},
+		},
+		{
+			name:  "nothing left",
+			value: "b",
+			table: map[byte]string{'b': "-"},
+			want:  nil,
+		},
+		{
+			name:  "no spelling possible",
+			value: "bib",
+			table: map[byte]string{},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandLookalikes(tt.value, tt.table)
+			if err != nil {
+				t.Fatalf("ExpandLookalikes() error = %v", err)
+			}
+			slices.Sort(got)
+			slices.Sort(tt.want)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ExpandLookalikes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandLookalikesLimit(t *testing.T) {
+	table := map[byte]string{'q': "pzry9x8gf2tvdw0s"}
+	if _, err := ExpandLookalikes("qqqqqqqq", table); err == nil {
+		t.Error("expected an error for too many variants")
+	}
+}
+
+func TestLookalikeGenerator(t *testing.T) {
+	patterns := []Pattern{{Value: "bo", Position: "end", Count: 2}}
+	g, err := NewLookalikeGenerator(patterns, map[byte]string{'b': "68", 'o': "0"}, false, "")
+	if err != nil {
+		t.Fatalf("NewLookalikeGenerator() error = %v", err)
+	}
+
+	if !g.isMatch("init1qqq60") || !g.isMatch("init1qqq80") {
+		t.Error("expected both variants to match")
+	}
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	results := g.GetResults()
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Pattern != "bo" {
+			t.Errorf("expected pattern 'bo', got '%s'", result.Pattern)
+		}
+		if result.Variant != "60" && result.Variant != "80" {
+			t.Errorf("unexpected variant '%s'", result.Variant)
+		}
+	}
+
+	if _, err := NewLookalikeGenerator([]Pattern{{Value: "bib", Position: "end", Count: 1}}, nil, false, ""); err == nil {
+		t.Error("expected an error for a pattern without lookalikes")
+	}
+}
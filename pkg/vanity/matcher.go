@@ -0,0 +1,126 @@
+package vanity
+
+// matcher finds every occurrence of a fixed set of patterns in a single pass
+// over the input using an Aho-Corasick automaton. The automaton is compiled
+// into a dense transition table over the bytes that actually appear in the
+// patterns, so scanning costs one table lookup per input byte regardless of
+// how many patterns are loaded.
+type matcher struct {
+	classes [256]int // byte -> alphabet class, 0 for bytes in no pattern
+	width   int      // number of alphabet classes
+	next    []int    // transitions, indexed by state*width + class
+	out     [][]int  // pattern indices that end at each state
+	depth   []int    // length of the pattern prefix each state stands for
+	lengths []int    // length of each pattern
+}
+
+// newMatcher builds a matcher for the given patterns. Pattern indices
+// reported by scan refer to positions in this slice.
+func newMatcher(patterns []string) *matcher {
+	m := &matcher{width: 1, lengths: make([]int, len(patterns))}
+	for _, p := range patterns {
+		for i := 0; i < len(p); i++ {
+			if m.classes[p[i]] == 0 {
+				m.classes[p[i]] = m.width
+				m.width++
+			}
+		}
+	}
+
+	// Build the trie. A transition of -1 means "not yet defined".
+	m.addState()
+	for idx, p := range patterns {
+		m.lengths[idx] = len(p)
+		state := 0
+		for i := 0; i < len(p); i++ {
+			slot := state*m.width + m.classes[p[i]]
+			if m.next[slot] < 0 {
+				m.next[slot] = m.addState()
+				m.depth[m.next[slot]] = m.depth[state] + 1
+			}
+			state = m.next[slot]
+		}
+		m.out[state] = append(m.out[state], idx)
+	}
+
+	// Resolve failure links breadth-first, turning the trie into a DFA.
+	fail := make([]int, len(m.out))
+	queue := make([]int, 0, len(m.out))
+	for c := 0; c < m.width; c++ {
+		if s := m.next[c]; s < 0 {
+			m.next[c] = 0
+		} else {
+			queue = append(queue, s)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		m.out[state] = append(m.out[state], m.out[fail[state]]...)
+		for c := 0; c < m.width; c++ {
+			slot := state*m.width + c
+			if s := m.next[slot]; s < 0 {
+				m.next[slot] = m.next[fail[state]*m.width+c]
+			} else {
+				fail[s] = m.next[fail[state]*m.width+c]
+				queue = append(queue, s)
+			}
+		}
+	}
+
+	return m
+}
+
+// addState appends an empty state and returns its index
+func (m *matcher) addState() int {
+	for c := 0; c < m.width; c++ {
+		m.next = append(m.next, -1)
+	}
+	m.out = append(m.out, nil)
+	m.depth = append(m.depth, 0)
+	return len(m.out) - 1
+}
+
+// scan calls fn for every pattern occurrence in text with the pattern index
+// and the offset at which the occurrence starts. Scanning stops early when fn
+// returns false.
+func (m *matcher) scan(text string, fn func(idx, start int) bool) {
+	state := 0
+	for i := 0; i < len(text); i++ {
+		state = m.next[state*m.width+m.classes[text[i]]]
+		for _, idx := range m.out[state] {
+			if !fn(idx, i+1-m.lengths[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// prefixLength returns the length of the longest prefix of text that is also
+// a prefix of a pattern. With reverse set, text is read from its end.
+func (m *matcher) prefixLength(text string, reverse bool) int {
+	state := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if reverse {
+			c = text[len(text)-1-i]
+		}
+		state = m.next[state*m.width+m.classes[c]]
+		if m.depth[state] != i+1 {
+			return i
+		}
+	}
+	return len(text)
+}
+
+// deepest returns the length of the longest pattern prefix that occurs
+// anywhere in text. The state after each byte stands for the longest suffix
+// of the text so far that is a pattern prefix, so this is its greatest depth.
+func (m *matcher) deepest(text string) int {
+	state, best := 0, 0
+	for i := 0; i < len(text); i++ {
+		state = m.next[state*m.width+m.classes[text[i]]]
+		best = max(best, m.depth[state])
+	}
+	return best
+}
@@ -0,0 +1,113 @@
+package vanity
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+type occurrence struct {
+	idx   int
+	start int
+}
+
+func TestMatcherScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		text     string
+		want     []occurrence
+	}{
+		{
+			name:     "single pattern",
+			patterns: []string{"dao"},
+			text:     "xxdaoxx",
+			want:     []occurrence{{0, 2}},
+		},
+		{
+			name:     "overlapping patterns",
+			patterns: []string{"he", "she", "hers"},
+			text:     "ushers",
+			want:     []occurrence{{1, 1}, {0, 2}, {2, 2}},
+		},
+		{
+			name:     "repeated occurrences",
+			patterns: []string{"aa"},
+			text:     "aaaa",
+			want:     []occurrence{{0, 0}, {0, 1}, {0, 2}},
+		},
+		{
+			name:     "no match",
+			patterns: []string{"abc", "xyz"},
+			text:     "qpzry9x8gf",
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMatcher(tt.patterns)
+			var got []occurrence
+			m.scan(tt.text, func(idx, start int) bool {
+				got = append(got, occurrence{idx, start})
+				return true
+			})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("scan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcherAgreesWithContains(t *testing.T) {
+	patterns := []string{"dao", "ini", "q", "zz", "lmn"}
+	texts := []string{"qpzry9x8gf2tvdw0s3jn54khce6mua7l", "daodaozz", "lmnopq", ""}
+
+	m := newMatcher(patterns)
+	for _, text := range texts {
+		seen := make([]bool, len(patterns))
+		m.scan(text, func(idx, start int) bool {
+			if text[start:start+len(patterns[idx])] != patterns[idx] {
+				t.Errorf("bad occurrence of %q at %d in %q", patterns[idx], start, text)
+			}
+			seen[idx] = true
+			return true
+		})
+		for i, p := range patterns {
+			if seen[i] != strings.Contains(text, p) {
+				t.Errorf("pattern %q in %q: matcher=%v contains=%v", p, text, seen[i], !seen[i])
+			}
+		}
+	}
+}
+
+func TestMatcherPrefixes(t *testing.T) {
+	m := newMatcher([]string{"dewmud", "dao", "ushers"})
+
+	tests := []struct {
+		name    string
+		text    string
+		reverse bool
+		prefix  int
+		deepest int
+	}{
+		{"full prefix", "dewmudqq", false, 6, 6},
+		{"partial prefix", "dewqqq", false, 3, 3},
+		{"no prefix", "qdaoqq", false, 0, 3},
+		{"shorter pattern hidden by longer", "qusdewq", false, 0, 3},
+		{"reversed", "qqoad", true, 3, 1},
+		{"outside alphabet", "xyz", false, 0, 0},
+		{"empty", "", false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.prefixLength(tt.text, tt.reverse); got != tt.prefix {
+				t.Errorf("prefixLength() = %d, want %d", got, tt.prefix)
+			}
+			if got := m.deepest(tt.text); got != tt.deepest {
+				t.Errorf("deepest() = %d, want %d", got, tt.deepest)
+			}
+		})
+	}
+}
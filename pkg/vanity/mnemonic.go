@@ -0,0 +1,224 @@
+package vanity
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultWords is the length of generated mnemonics unless SetWords says
+// otherwise
+const DefaultWords = 24
+
+// Mnemonic languages, named after the BIP39 wordlists
+const (
+	LanguageEnglish            = "english"
+	LanguageJapanese           = "japanese"
+	LanguageSpanish            = "spanish"
+	LanguageFrench             = "french"
+	LanguageItalian            = "italian"
+	LanguageKorean             = "korean"
+	LanguageChineseSimplified  = "chinese-simplified"
+	LanguageChineseTraditional = "chinese-traditional"
+	LanguageCzech              = "czech"
+)
+
+// wordlistSources holds the BIP39 wordlist of each language
+var wordlistSources = map[string][]string{
+	LanguageEnglish:            wordlists.English,
+	LanguageJapanese:           wordlists.Japanese,
+	LanguageSpanish:            wordlists.Spanish,
+	LanguageFrench:             wordlists.French,
+	LanguageItalian:            wordlists.Italian,
+	LanguageKorean:             wordlists.Korean,
+	LanguageChineseSimplified:  wordlists.ChineseSimplified,
+	LanguageChineseTraditional: wordlists.ChineseTraditional,
+	LanguageCzech:              wordlists.Czech,
+}
+
+// wordlist is a BIP39 wordlist. Its words are kept in NFC, the form people
+// type, and indexed by their NFKD form, the form BIP39 hashes.
+type wordlist struct {
+	words     []string
+	index     map[string]int
+	separator string
+}
+
+var (
+	wordlistsMu    sync.Mutex
+	wordlistsCache = map[string]*wordlist{}
+)
+
+// Languages returns the supported mnemonic languages
+func Languages() []string {
+	languages := make([]string, 0, len(wordlistSources))
+	for language := range wordlistSources {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// ValidateLanguage checks that a mnemonic language is supported
+func ValidateLanguage(language string) error {
+	if _, ok := wordlistSources[language]; !ok {
+		return fmt.Errorf("unsupported mnemonic language '%s': must be one of: %s", language, strings.Join(Languages(), ", "))
+	}
+	return nil
+}
+
+// loadWordlist returns the wordlist of a language, indexing it on first use
+func loadWordlist(language string) (*wordlist, error) {
+	wordlistsMu.Lock()
+	defer wordlistsMu.Unlock()
+
+	if wl, ok := wordlistsCache[language]; ok {
+		return wl, nil
+	}
+	if err := ValidateLanguage(language); err != nil {
+		return nil, err
+	}
+	words := wordlistSources[language]
+
+	wl := &wordlist{words: make([]string, len(words)), index: make(map[string]int, len(words)), separator: " "}
+	for i, word := range words {
+		wl.words[i] = norm.NFC.String(word)
+		wl.index[norm.NFKD.String(word)] = i
+	}
+	// Japanese mnemonics are written with ideographic spaces
+	if language == LanguageJapanese {
+		wl.separator = "　"
+	}
+	wordlistsCache[language] = wl
+	return wl, nil
+}
+
+// EntropyBits returns the entropy behind a BIP39 mnemonic of the given
+// number of words: 12, 15, 18, 21 or 24
+func EntropyBits(words int) (int, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return 0, fmt.Errorf("mnemonic length must be 12, 15, 18, 21 or 24 words, got %d", words)
+	}
+	return words / 3 * 32, nil
+}
+
+// NewMnemonic encodes entropy of 128 to 256 bits, in steps of 32, as a
+// BIP39 mnemonic in the given language
+func NewMnemonic(entropy []byte, language string) (string, error) {
+	bits := len(entropy) * 8
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", fmt.Errorf("entropy must be 128 to 256 bits in steps of 32, got %d", bits)
+	}
+	wl, err := loadWordlist(language)
+	if err != nil {
+		return "", err
+	}
+
+	// The checksum is the first bits/32 bits of the entropy's SHA-256
+	checksumBits := bits / 32
+	sum := sha256.Sum256(entropy)
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, uint(checksumBits))
+	data.Or(data, big.NewInt(int64(sum[0]>>(8-checksumBits))))
+
+	n := (bits + checksumBits) / 11
+	words := make([]string, n)
+	mask := big.NewInt(2047)
+	for i := n - 1; i >= 0; i-- {
+		words[i] = wl.words[new(big.Int).And(data, mask).Int64()]
+		data.Rsh(data, 11)
+	}
+	return strings.Join(words, wl.separator), nil
+}
+
+// ValidateMnemonic checks that a mnemonic consists of words from the
+// language's wordlist and that its checksum is correct. Words are compared
+// after NFKD normalization, and any whitespace, including the ideographic
+// space, separates them.
+func ValidateMnemonic(mnemonic, language string) error {
+	wl, err := loadWordlist(language)
+	if err != nil {
+		return err
+	}
+
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if _, err := EntropyBits(len(words)); err != nil {
+		return err
+	}
+
+	data := new(big.Int)
+	for _, word := range words {
+		i, ok := wl.index[word]
+		if !ok {
+			return fmt.Errorf("'%s' is not in the %s wordlist", word, language)
+		}
+		data.Lsh(data, 11)
+		data.Or(data, big.NewInt(int64(i)))
+	}
+
+	checksumBits := len(words) / 3
+	checksum := new(big.Int).And(data, big.NewInt(1<<checksumBits-1)).Int64()
+	entropy := data.Rsh(data, uint(checksumBits)).FillBytes(make([]byte, len(words)*4/3))
+	sum := sha256.Sum256(entropy)
+	if int64(sum[0]>>(8-checksumBits)) != checksum {
+		return fmt.Errorf("invalid mnemonic checksum")
+	}
+	return nil
+}
+
+// MnemonicSeed derives the BIP39 seed of a mnemonic, normalizing it and the
+// passphrase to NFKD first
+func MnemonicSeed(mnemonic, passphrase string) []byte {
+	password := []byte(norm.NFKD.String(mnemonic))
+	salt := []byte(norm.NFKD.String("mnemonic" + passphrase))
+	return pbkdf2.Key(password, salt, 2048, 64, sha512.New)
+}
+
+// SetWords sets the number of words in generated mnemonics. A supplied
+// mnemonic must have this many words too. It must be called before
+// Generate.
+func (g *Generator) SetWords(words int) error {
+	if _, err := EntropyBits(words); err != nil {
+		return err
+	}
+	g.words = words
+	return nil
+}
+
+// SetLanguage sets the wordlist of generated and supplied mnemonics,
+// English by default. It must be called before Generate.
+func (g *Generator) SetLanguage(language string) error {
+	if _, err := loadWordlist(language); err != nil {
+		return err
+	}
+	g.language = language
+	return nil
+}
+
+// mnemonicLanguage returns the language of the generator's mnemonics
+func (g *Generator) mnemonicLanguage() string {
+	if g.language == "" {
+		return LanguageEnglish
+	}
+	return g.language
+}
+
+// checkMnemonic validates a supplied mnemonic, including its length if one
+// was chosen with SetWords
+func (g *Generator) checkMnemonic(mnemonic string) error {
+	if err := ValidateMnemonic(mnemonic, g.mnemonicLanguage()); err != nil {
+		return fmt.Errorf("invalid mnemonic provided: %v", err)
+	}
+	if n := len(strings.Fields(mnemonic)); g.words != 0 && n != g.words {
+		return fmt.Errorf("mnemonic has %d words, expected %d", n, g.words)
+	}
+	return nil
+}
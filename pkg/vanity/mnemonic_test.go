@@ -0,0 +1,259 @@
+package vanity
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/go-bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+const abandon12 = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestEntropyBits(t *testing.T) {
+	tests := []struct {
+		words   int
+		want    int
+		wantErr bool
+	}{
+		{12, 128, false},
+		{15, 160, false},
+		{18, 192, false},
+		{21, 224, false},
+		{24, 256, false},
+		{9, 0, true},
+		{13, 0, true},
+		{27, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := EntropyBits(tt.words)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("EntropyBits(%d) error = %v, wantErr %v", tt.words, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("EntropyBits(%d) = %d, want %d", tt.words, got, tt.want)
+		}
+	}
+}
+
+func TestSetWords(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		g := NewGenerator("test", "end", false, 1, true, "")
+		if err := g.SetWords(words); err != nil {
+			t.Fatalf("SetWords(%d) error = %v", words, err)
+		}
+		mnemonic, err := g.generateMnemonic()
+		if err != nil {
+			t.Fatalf("generateMnemonic() error = %v", err)
+		}
+		if n := len(strings.Fields(mnemonic)); n != words {
+			t.Errorf("SetWords(%d) generated %d words", words, n)
+		}
+	}
+
+	g := NewGenerator("test", "end", false, 1, true, "")
+	if err := g.SetWords(20); err == nil {
+		t.Error("SetWords(20) did not fail")
+	}
+	mnemonic, _ := g.generateMnemonic()
+	if n := len(strings.Fields(mnemonic)); n != DefaultWords {
+		t.Errorf("default mnemonic has %d words, want %d", n, DefaultWords)
+	}
+}
+
+func TestCheckMnemonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		words    int
+		mnemonic string
+		wantErr  bool
+	}{
+		{"any length", 0, abandon12, false},
+		{"chosen length", 12, abandon12, false},
+		{"other length", 24, abandon12, true},
+		{"unknown word", 12, strings.Replace(abandon12, "about", "initia", 1), true},
+		{"bad checksum", 12, strings.Replace(abandon12, "about", "abandon", 1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator("test", "end", false, 1, true, tt.mnemonic)
+			g.words = tt.words
+			if err := g.checkMnemonic(tt.mnemonic); (err != nil) != tt.wantErr {
+				t.Errorf("checkMnemonic() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	japanese := strings.Repeat("あいこくしん　", 11) + "あおぞら"
+
+	tests := []struct {
+		name     string
+		entropy  string
+		language string
+		want     string
+	}{
+		{"zero", "00000000000000000000000000000000", LanguageEnglish, abandon12},
+		{"7f", "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", LanguageEnglish,
+			"legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"24 words", strings.Repeat("ff", 32), LanguageEnglish, strings.Repeat("zoo ", 23) + "vote"},
+		{"japanese", "00000000000000000000000000000000", LanguageJapanese, japanese},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entropy, _ := hex.DecodeString(tt.entropy)
+			got, err := NewMnemonic(entropy, tt.language)
+			if err != nil {
+				t.Fatalf("NewMnemonic() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NewMnemonic() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewMnemonic(make([]byte, 15), LanguageEnglish); err == nil {
+		t.Error("NewMnemonic() accepted 120 bits of entropy")
+	}
+	if _, err := NewMnemonic(make([]byte, 16), "klingon"); err == nil {
+		t.Error("NewMnemonic() accepted an unsupported language")
+	}
+}
+
+func TestNewMnemonicMatchesBIP39(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		entropy, _ := bip39.NewEntropy(bits)
+		want, _ := bip39.NewMnemonic(entropy)
+		got, err := NewMnemonic(entropy, LanguageEnglish)
+		if err != nil {
+			t.Fatalf("NewMnemonic() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("NewMnemonic(%x) = %q, want %q", entropy, got, want)
+		}
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	japanese := strings.Repeat("あいこくしん　", 11) + "あおぞら"
+
+	tests := []struct {
+		name     string
+		mnemonic string
+		language string
+		wantErr  bool
+	}{
+		{"english", abandon12, LanguageEnglish, false},
+		{"bad checksum", strings.Replace(abandon12, "about", "abandon", 1), LanguageEnglish, true},
+		{"unknown word", strings.Replace(abandon12, "about", "initia", 1), LanguageEnglish, true},
+		{"too short", "abandon about", LanguageEnglish, true},
+		{"japanese", japanese, LanguageJapanese, false},
+		{"japanese with ascii spaces", strings.ReplaceAll(japanese, "　", " "), LanguageJapanese, false},
+		{"japanese decomposed", norm.NFKD.String(japanese), LanguageJapanese, false},
+		{"wrong wordlist", abandon12, LanguageJapanese, true},
+		{"unsupported language", abandon12, "klingon", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateMnemonic(tt.mnemonic, tt.language); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMnemonic() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Every language must round-trip
+	entropy, _ := bip39.NewEntropy(256)
+	for _, language := range Languages() {
+		mnemonic, err := NewMnemonic(entropy, language)
+		if err != nil {
+			t.Fatalf("NewMnemonic(%s) error = %v", language, err)
+		}
+		if err := ValidateMnemonic(mnemonic, language); err != nil {
+			t.Errorf("ValidateMnemonic(%s) error = %v", language, err)
+		}
+	}
+}
+
+func TestMnemonicSeed(t *testing.T) {
+	tests := []struct {
+		name       string
+		mnemonic   string
+		passphrase string
+		want       string
+	}{
+		{
+			name:       "english",
+			mnemonic:   abandon12,
+			passphrase: "TREZOR",
+			want:       "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			name:       "japanese",
+			mnemonic:   strings.Repeat("あいこくしん　", 11) + "あおぞら",
+			passphrase: "㍍ガバヴァぱばぐゞちぢ十人十色",
+			want:       "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hex.EncodeToString(MnemonicSeed(tt.mnemonic, tt.passphrase)); got != tt.want {
+				t.Errorf("MnemonicSeed() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLanguage(t *testing.T) {
+	g := NewGenerator("test", "end", false, 1, true, "")
+	if err := g.SetLanguage("klingon"); err == nil {
+		t.Error("SetLanguage() accepted an unsupported language")
+	}
+	if err := g.SetLanguage(LanguageJapanese); err != nil {
+		t.Fatalf("SetLanguage() error = %v", err)
+	}
+	if err := g.SetWords(12); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, _, mnemonic, _, err := g.generateAddressFromMnemonic()
+	if err != nil {
+		t.Fatalf("generateAddressFromMnemonic() error = %v", err)
+	}
+	if err := ValidateMnemonic(mnemonic, LanguageJapanese); err != nil {
+		t.Errorf("generated mnemonic is not Japanese: %v", err)
+	}
+	if n := len(strings.Fields(mnemonic)); n != 12 {
+		t.Errorf("generated %d words, want 12", n)
+	}
+
+	// A supplied mnemonic is checked against the chosen wordlist
+	g = NewGenerator("test", "end", false, 1, true, abandon12)
+	g.SetLanguage(LanguageJapanese)
+	if _, _, _, _, _, err := g.generateAddressFromMnemonic(); err == nil {
+		t.Error("English mnemonic accepted as Japanese")
+	}
+}
+
+func TestMnemonicAddress(t *testing.T) {
+	// The well-known first Cosmos address of the all-abandon mnemonic
+	_, raw, _ := bech32.DecodeAndConvert("cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4")
+	want, _ := bech32.ConvertAndEncode(AccountHRP, raw)
+
+	g := NewGenerator("test", "end", false, 1, true, abandon12)
+	address, _, _, _, _, err := g.generateAddressFromMnemonic()
+	if err != nil {
+		t.Fatalf("generateAddressFromMnemonic() error = %v", err)
+	}
+	if address != want {
+		t.Errorf("address = %s, want %s", address, want)
+	}
+}
@@ -0,0 +1,119 @@
+package vanity
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// Secp256k1PubKeyType is the type URL of Cosmos SDK secp256k1 public keys
+const Secp256k1PubKeyType = "/cosmos.crypto.secp256k1.PubKey"
+
+// ParsePubKey parses a secp256k1 public key, either as the JSON shown by
+// `keys show --pubkey`, {"@type":"/cosmos.crypto.secp256k1.PubKey","key":...},
+// or as the base64 of its 33-byte compressed form
+func ParsePubKey(s string) (cryptotypes.PubKey, error) {
+	encoded := s
+	var doc struct {
+		Type string `json:"@type"`
+		Key  string `json:"key"`
+	}
+	if err := json.Unmarshal([]byte(s), &doc); err == nil {
+		if doc.Type != Secp256k1PubKeyType {
+			return nil, fmt.Errorf("unsupported public key type %q", doc.Type)
+		}
+		encoded = doc.Key
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	if len(raw) != secp256k1.PubKeySize {
+		return nil, fmt.Errorf("invalid public key: expected %d bytes, got %d", secp256k1.PubKeySize, len(raw))
+	}
+	return &secp256k1.PubKey{Key: raw}, nil
+}
+
+// multisigSource generates the last member key of a multisig and yields the
+// address of the LegacyAminoPubKey formed with the fixed members
+type multisigSource struct {
+	g         *Generator
+	threshold int
+	members   []cryptotypes.PubKey
+	cdc       codec.Codec
+}
+
+func (s multisigSource) next(yield yieldFunc) error {
+	privKey, err := s.g.newPrivKey()
+	if err != nil {
+		return err
+	}
+	pubKey := privKey.PubKey()
+
+	pubKeys := append(append([]cryptotypes.PubKey(nil), s.members...), pubKey)
+	multisig := kmultisig.NewLegacyAminoPubKey(s.threshold, pubKeys)
+	address, err := bech32.ConvertAndEncode(AccountHRP, multisig.Address())
+	if err != nil {
+		return err
+	}
+
+	yield(candidate{address: address, fill: func(r *Result) {
+		// Encoding well-formed keys cannot fail
+		pubKeyJSON, _ := s.cdc.MarshalInterfaceJSON(pubKey)
+		multisigJSON, _ := s.cdc.MarshalInterfaceJSON(multisig)
+		r.PrivateKey = hex.EncodeToString(privKey.Bytes())
+		r.PublicKey = string(pubKeyJSON)
+		r.MultisigPubKey = string(multisigJSON)
+	}})
+	return nil
+}
+
+func (multisigSource) alphabet() string {
+	return Bech32Charset
+}
+
+func (multisigSource) dataLen() int {
+	return addressDataLen
+}
+
+// SetMultisig switches the generator to threshold-of-N multisig addresses,
+// where N is one more than the number of fixed members. The fixed members
+// come first, in the order given, and a freshly generated key is the last
+// member. It must be called before Generate.
+func (g *Generator) SetMultisig(threshold int, members []string) error {
+	if len(members) == 0 {
+		return fmt.Errorf("multisig needs at least one fixed member")
+	}
+	if threshold < 1 || threshold > len(members)+1 {
+		return fmt.Errorf("threshold must be between 1 and %d", len(members)+1)
+	}
+
+	pubKeys := make([]cryptotypes.PubKey, len(members))
+	for i, member := range members {
+		pubKey, err := ParsePubKey(member)
+		if err != nil {
+			return fmt.Errorf("member %d: %v", i+1, err)
+		}
+		pubKeys[i] = pubKey
+	}
+
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	g.setSource(multisigSource{
+		g:         g,
+		threshold: threshold,
+		members:   pubKeys,
+		cdc:       codec.NewProtoCodec(registry),
+	})
+	return nil
+}
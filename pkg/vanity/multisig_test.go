@@ -0,0 +1,133 @@
+package vanity
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+func TestParsePubKey(t *testing.T) {
+	key := secp256k1.GenPrivKeyFromSecret([]byte("member")).PubKey().Bytes()
+	encoded := base64.StdEncoding.EncodeToString(key)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"json", `{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"` + encoded + `"}`, false},
+		{"base64", encoded, false},
+		{"other key type", `{"@type":"/cosmos.crypto.ed25519.PubKey","key":"` + encoded + `"}`, true},
+		{"wrong length", base64.StdEncoding.EncodeToString(key[:32]), true},
+		{"not base64", "init1...", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pubKey, err := ParsePubKey(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePubKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && string(pubKey.Bytes()) != string(key) {
+				t.Errorf("ParsePubKey() = %x, want %x", pubKey.Bytes(), key)
+			}
+		})
+	}
+}
+
+func TestSetMultisig(t *testing.T) {
+	member := base64.StdEncoding.EncodeToString(secp256k1.GenPrivKey().PubKey().Bytes())
+
+	tests := []struct {
+		name      string
+		threshold int
+		members   []string
+		wantErr   bool
+	}{
+		{"2 of 3", 2, []string{member, member}, false},
+		{"all members", 2, []string{member}, false},
+		{"no fixed members", 1, nil, true},
+		{"zero threshold", 0, []string{member}, true},
+		{"threshold above members", 3, []string{member}, true},
+		{"invalid member", 1, []string{"cafe"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator("q", "start", false, 1, false, "")
+			if err := g.SetMultisig(tt.threshold, tt.members); (err != nil) != tt.wantErr {
+				t.Errorf("SetMultisig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// aminoMultisigAddress computes the address of a LegacyAminoPubKey from its
+// amino encoding, independently of the SDK
+func aminoMultisigAddress(threshold byte, keys [][]byte) []byte {
+	enc := []byte{0x22, 0xc1, 0xf7, 0xe2, 0x08, threshold}
+	for _, key := range keys {
+		enc = append(enc, 0x12, byte(4+1+len(key)), 0xeb, 0x5a, 0xe9, 0x87, byte(len(key)))
+		enc = append(enc, key...)
+	}
+	sum := sha256.Sum256(enc)
+	return sum[:20]
+}
+
+func TestGenerateMultisig(t *testing.T) {
+	fixed := [][]byte{
+		secp256k1.GenPrivKeyFromSecret([]byte("alice")).PubKey().Bytes(),
+		secp256k1.GenPrivKeyFromSecret([]byte("bob")).PubKey().Bytes(),
+	}
+	members := []string{
+		base64.StdEncoding.EncodeToString(fixed[0]),
+		base64.StdEncoding.EncodeToString(fixed[1]),
+	}
+
+	g := NewGenerator("q", "start", false, 1, false, "")
+	if err := g.SetMultisig(2, members); err != nil {
+		t.Fatalf("SetMultisig() error = %v", err)
+	}
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	result := g.GetResults()[0]
+	if !strings.HasPrefix(result.Address, "init1q") {
+		t.Errorf("address does not match: %s", result.Address)
+	}
+
+	// The multisig JSON must decode to the same key, with the generated
+	// member last
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	var multisig cryptotypes.PubKey
+	if err := cdc.UnmarshalInterfaceJSON([]byte(result.MultisigPubKey), &multisig); err != nil {
+		t.Fatalf("multisig public key does not decode: %v", err)
+	}
+	var member cryptotypes.PubKey
+	if err := cdc.UnmarshalInterfaceJSON([]byte(result.PublicKey), &member); err != nil {
+		t.Fatalf("member public key does not decode: %v", err)
+	}
+
+	want, err := bech32.ConvertAndEncode(AccountHRP, aminoMultisigAddress(2, append(fixed, member.Bytes())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Address != want {
+		t.Errorf("address = %s, want %s", result.Address, want)
+	}
+	if got, _ := bech32.ConvertAndEncode(AccountHRP, multisig.Address()); got != want {
+		t.Errorf("multisig public key address = %s, want %s", got, want)
+	}
+}
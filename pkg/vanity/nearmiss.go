@@ -0,0 +1,125 @@
+package vanity
+
+import (
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// partialLength returns how much of w matches data at the given position:
+// the longest matched prefix of the pattern at the start or anywhere, and the
+// longest matched suffix at the end
+func partialLength(w window, position string, data string) int {
+	n := 0
+	for n < w.size() {
+		part := w.prefix(n + 1)
+		if position == "end" {
+			part = w.suffix(n + 1)
+		}
+		if _, ok := findWindow(part, position, data); !ok {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// partials measures near misses of literal patterns with one automaton per
+// position, so its cost does not grow with the number of patterns. End
+// patterns are reversed so their suffixes become prefixes. Wildcards and
+// pattern classes are measured one by one.
+type partials struct {
+	start, end, any *matcher
+	others          []int
+}
+
+// compilePartials builds the near-miss automatons for patterns written in
+// alphabet. Patterns that can never match are left out.
+func compilePartials(patterns []Pattern, caseSensitive bool, alphabet string) partials {
+	var ps partials
+	var start, end, any []string
+	for idx, p := range patterns {
+		value := p.Value
+		if !caseSensitive {
+			value = strings.ToLower(value)
+		}
+		if _, err := compileWindow(value, alphabet); err != nil {
+			continue
+		}
+		if !isLiteral(value) {
+			ps.others = append(ps.others, idx)
+			continue
+		}
+		switch p.Position {
+		case "start":
+			start = append(start, value)
+		case "end":
+			end = append(end, reverse(value))
+		default:
+			any = append(any, value)
+		}
+	}
+	if len(start) > 0 {
+		ps.start = newMatcher(start)
+	}
+	if len(end) > 0 {
+		ps.end = newMatcher(end)
+	}
+	if len(any) > 0 {
+		ps.any = newMatcher(any)
+	}
+	return ps
+}
+
+// reverse returns s with its bytes in reverse order
+func reverse(s string) string {
+	b := []byte(s)
+	slices.Reverse(b)
+	return string(b)
+}
+
+// trackNearMiss records how close an address came to matching. The histogram
+// counts attempts by their longest partial match, which for a healthy RNG
+// falls off geometrically, and the closest address seen is kept in Stats.
+// Dictionary searches are not tracked since their wordlists are too large to
+// test word by word.
+func (g *Generator) trackNearMiss(address string) {
+	if g.dictionary || len(g.patterns) == 0 {
+		return
+	}
+	data := address
+	if !g.caseSensitive {
+		data = strings.ToLower(data)
+	}
+	best := g.partialMatch(dataPart(data))
+	atomic.AddUint64(&g.stats.Histogram[best], 1)
+
+	if int64(best) <= g.nearMiss.Load() {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if best > g.stats.BestPartial {
+		g.stats.BestPartial = best
+		g.stats.BestPartialAddress = address
+		g.nearMiss.Store(int64(best))
+	}
+}
+
+// partialMatch returns the longest partial match of any pattern in data
+func (g *Generator) partialMatch(data string) int {
+	best := 0
+	if g.partials.start != nil {
+		best = g.partials.start.prefixLength(data, false)
+	}
+	if g.partials.end != nil {
+		best = max(best, g.partials.end.prefixLength(data, true))
+	}
+	if g.partials.any != nil {
+		best = max(best, g.partials.any.deepest(data))
+	}
+	for _, idx := range g.partials.others {
+		best = max(best, partialLength(g.windows[idx], g.patterns[idx].Position, data))
+	}
+	return best
+}
@@ -0,0 +1,147 @@
+package vanity
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestPartialLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		position string
+		data     string
+		want     int
+	}{
+		{"full start", "dew", "start", "dewqqq", 3},
+		{"partial start", "dew", "start", "deqqqq", 2},
+		{"no start", "dew", "start", "qdewqq", 0},
+		{"partial end", "dew", "end", "qqqqew", 2},
+		{"prefix at end is not a suffix", "dew", "end", "qqqqde", 0},
+		{"partial anywhere", "dewmud", "any", "qqdewmqq", 4},
+		{"wildcard start", "d?w", "start", "dxqqqq", 2},
+		{"class end", "repeat:4", "end", "qpzzz", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := compileWindow(tt.pattern, Bech32Charset)
+			if err != nil {
+				t.Fatalf("compileWindow() error = %v", err)
+			}
+			if got := partialLength(w, tt.position, tt.data); got != tt.want {
+				t.Errorf("partialLength() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrackNearMiss(t *testing.T) {
+	g := NewGenerator("dewmud", "start", false, 1, false, "")
+
+	addresses := []string{
+		"init1qqqqqq",
+		"init1dqqqqq",
+		"init1DEWqqq",
+		"init1deqqqq",
+	}
+	for _, address := range addresses {
+		g.trackNearMiss(address)
+	}
+
+	stats := g.GetStats()
+	if stats.BestPartial != 3 {
+		t.Errorf("BestPartial = %d, want 3", stats.BestPartial)
+	}
+	if stats.BestPartialAddress != "init1DEWqqq" {
+		t.Errorf("BestPartialAddress = %s, want init1DEWqqq", stats.BestPartialAddress)
+	}
+
+	want := []uint64{1, 1, 1, 1, 0, 0, 0}
+	if len(stats.Histogram) != len(want) {
+		t.Fatalf("len(Histogram) = %d, want %d", len(stats.Histogram), len(want))
+	}
+	for n := range want {
+		if stats.Histogram[n] != want[n] {
+			t.Errorf("Histogram[%d] = %d, want %d", n, stats.Histogram[n], want[n])
+		}
+	}
+}
+
+// randomData returns n random bech32 characters
+func randomData(rng *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = Bech32Charset[rng.Intn(len(Bech32Charset))]
+	}
+	return string(b)
+}
+
+func TestPartialMatchAgreesWithPartialLength(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var patterns []Pattern
+	for _, position := range []string{"start", "end", "any"} {
+		for i := 0; i < 20; i++ {
+			patterns = append(patterns, Pattern{Value: randomData(rng, 2+rng.Intn(4)), Position: position, Count: 1})
+		}
+	}
+	patterns = append(patterns,
+		Pattern{Value: "Q?p", Position: "any", Count: 1},
+		Pattern{Value: "repeat:4", Position: "end", Count: 1},
+		Pattern{Value: "qb1", Position: "start", Count: 1},
+	)
+	g := NewMultiGenerator(patterns, false, false, "")
+
+	for i := 0; i < 2000; i++ {
+		// Short runs of a few characters make partial matches common
+		data := randomData(rand.New(rand.NewSource(int64(i%50))), 4) + randomData(rng, addressDataLen-4)
+		want := 0
+		for idx, p := range g.patterns {
+			want = max(want, partialLength(g.windows[idx], p.Position, data))
+		}
+		if got := g.partialMatch(data); got != want {
+			t.Fatalf("partialMatch(%q) = %d, want %d", data, got, want)
+		}
+	}
+}
+
+// BenchmarkTrackNearMiss guards the cost of near-miss tracking per attempt,
+// which must not grow with the number of literal patterns
+func BenchmarkTrackNearMiss(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	variants, err := ExpandLookalikes("alicebobcarolllll", DefaultLookalikes)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		patterns []Pattern
+	}{
+		{"1 pattern", []Pattern{{Value: "dewmud", Position: "any", Count: 1}}},
+		{"100 patterns", nil},
+		{fmt.Sprintf("%d lookalikes", len(variants)), nil},
+	}
+	for i := 0; i < 100; i++ {
+		cases[1].patterns = append(cases[1].patterns, Pattern{Value: randomData(rng, 6), Position: "any", Count: 1})
+	}
+	for _, v := range variants {
+		cases[2].patterns = append(cases[2].patterns, Pattern{Value: v, Position: "end", Count: 1})
+	}
+
+	addresses := make([]string, 256)
+	for i := range addresses {
+		addresses[i] = "init1" + randomData(rng, addressDataLen)
+	}
+
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			g := NewMultiGenerator(c.patterns, false, false, "")
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				g.trackNearMiss(addresses[i%len(addresses)])
+			}
+		})
+	}
+}
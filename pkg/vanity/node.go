@@ -0,0 +1,61 @@
+package vanity
+
+import (
+	"encoding/hex"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+// NodeKeyFile is the name CometBFT gives a node's peer key
+const NodeKeyFile = "node_key.json"
+
+// NodeKey returns the node_key.json document for a node key in CometBFT's
+// amino JSON
+func NodeKey(privKey ed25519.PrivKey) ([]byte, error) {
+	return cmtjson.MarshalIndent(p2p.NodeKey{PrivKey: privKey}, "", "  ")
+}
+
+// nodeSource generates ed25519 node keys and yields their node IDs, the hex
+// of the first 20 bytes of the SHA-256 of the public key. IDs are matched
+// with a 0x prefix so the data part is the whole ID, and reported without
+// it.
+type nodeSource struct {
+	g *Generator
+}
+
+func (s nodeSource) next(yield yieldFunc) error {
+	privKey, err := s.g.newEd25519Key()
+	if err != nil {
+		return err
+	}
+	id := hex.EncodeToString(privKey.PubKey().Address())
+
+	yield(candidate{address: "0x" + id, fill: func(r *Result) {
+		r.Address = id
+		r.PrivateKey = hex.EncodeToString(privKey.Bytes())
+		r.KeyFileName = NodeKeyFile
+		// A key that fails to encode leaves KeyFile empty, which
+		// output.WriteKeyFiles reports
+		if keyFile, err := NodeKey(privKey); err == nil {
+			r.KeyFile = keyFile
+		}
+	}})
+	return nil
+}
+
+func (nodeSource) alphabet() string {
+	return HexCharset
+}
+
+func (nodeSource) dataLen() int {
+	return 2 * p2p.IDByteLength
+}
+
+// SetNode switches the generator to CometBFT node keys, matched on their
+// hex node ID. Results carry the key as a node_key.json document. It must be
+// called before Generate.
+func (g *Generator) SetNode() {
+	g.setSource(nodeSource{g: g})
+}
@@ -0,0 +1,72 @@
+package vanity
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+func TestNodeKey(t *testing.T) {
+	privKey := ed25519.GenPrivKeyFromSecret([]byte("initia-vanity"))
+
+	keyFile, err := NodeKey(privKey)
+	if err != nil {
+		t.Fatalf("NodeKey() error = %v", err)
+	}
+	if !strings.Contains(string(keyFile), ed25519.PrivKeyName) {
+		t.Errorf("key file has no amino type: %s", keyFile)
+	}
+
+	// CometBFT must be able to load the file back
+	var nodeKey p2p.NodeKey
+	if err := cmtjson.Unmarshal(keyFile, &nodeKey); err != nil {
+		t.Fatalf("CometBFT cannot read key file: %v", err)
+	}
+	if !nodeKey.PrivKey.Equals(privKey) {
+		t.Error("key file holds a different private key")
+	}
+}
+
+func TestGenerateNode(t *testing.T) {
+	g := NewGenerator("a", "start", false, 1, false, "")
+	g.SetNode()
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	result := g.GetResults()[0]
+	if len(result.Address) != 40 || !strings.HasPrefix(result.Address, "a") {
+		t.Errorf("node ID does not match: %s", result.Address)
+	}
+	if result.KeyFileName != NodeKeyFile {
+		t.Errorf("key file name = %s, want %s", result.KeyFileName, NodeKeyFile)
+	}
+
+	var nodeKey p2p.NodeKey
+	if err := cmtjson.Unmarshal(result.KeyFile, &nodeKey); err != nil {
+		t.Fatalf("CometBFT cannot read key file: %v", err)
+	}
+	if id := string(nodeKey.ID()); id != result.Address {
+		t.Errorf("key file node ID %s, want %s", id, result.Address)
+	}
+}
+
+func TestNodeDifficulty(t *testing.T) {
+	g := NewGenerator("00", "start", false, 1, false, "")
+	g.SetNode()
+	if d := g.Difficulty(); d != 256 {
+		t.Errorf("Difficulty() = %v, want 256", d)
+	}
+
+	// g is not a hex digit
+	g = NewGenerator("g", "start", false, 1, false, "")
+	g.SetNode()
+	if d := g.Difficulty(); !math.IsInf(d, 1) {
+		t.Errorf("Difficulty() = %v, want infinite", d)
+	}
+}
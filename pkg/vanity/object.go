@@ -0,0 +1,164 @@
+package vanity
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"golang.org/x/crypto/sha3"
+)
+
+// Address encodings for searches over 32-byte Move addresses
+const (
+	EncodingBech32 = "bech32" // init1 followed by 58 bech32 characters
+	EncodingHex    = "hex"    // 0x followed by 64 lowercase hex digits
+)
+
+// HexCharset is the alphabet used by hex-encoded addresses
+const HexCharset = "0123456789abcdef"
+
+// objectFromSeedScheme is the domain separator Move appends when deriving a
+// named object address from its creator and seed
+const objectFromSeedScheme = 0xFE
+
+// objectSeedBytes is the number of random bytes behind each candidate seed
+const objectSeedBytes = 12
+
+// ParseMoveAddress parses an init1 bech32 or 0x hex address into the 32-byte
+// form Move uses, left-padding shorter account addresses with zeros
+func ParseMoveAddress(s string) ([]byte, error) {
+	var raw []byte
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		digits := s[2:]
+		if len(digits)%2 == 1 {
+			digits = "0" + digits
+		}
+		var err error
+		raw, err = hex.DecodeString(digits)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex address '%s': %v", s, err)
+		}
+	} else {
+		hrp, bz, err := bech32.DecodeAndConvert(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bech32 address '%s': %v", s, err)
+		}
+		if hrp != AccountHRP {
+			return nil, fmt.Errorf("address '%s' has prefix '%s', expected 'init'", s, hrp)
+		}
+		raw = bz
+	}
+
+	if len(raw) > 32 {
+		return nil, fmt.Errorf("address '%s' is longer than 32 bytes", s)
+	}
+	addr := make([]byte, 32)
+	copy(addr[32-len(raw):], raw)
+	return addr, nil
+}
+
+// ObjectAddress returns the address of the named object that
+// object::create_named_object creates for creator and seed, i.e.
+// sha3-256(creator || seed || 0xFE)
+func ObjectAddress(creator, seed []byte) []byte {
+	h := sha3.New256()
+	h.Write(creator)
+	h.Write(seed)
+	h.Write([]byte{objectFromSeedScheme})
+	return h.Sum(nil)
+}
+
+// encodeAddress formats raw address bytes in the given encoding
+func encodeAddress(addr []byte, encoding string) (string, error) {
+	if encoding == EncodingHex {
+		return "0x" + hex.EncodeToString(addr), nil
+	}
+	return bech32.ConvertAndEncode(AccountHRP, addr)
+}
+
+// decodeAddress returns the raw bytes of a hex or bech32 address, or nil if
+// it can't be decoded
+func decodeAddress(address string) []byte {
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		raw, err := hex.DecodeString(address[2:])
+		if err != nil {
+			return nil
+		}
+		return raw
+	}
+	_, raw, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+// encodingAlphabet returns the characters an encoding writes data in
+func encodingAlphabet(encoding string) string {
+	if encoding == EncodingHex {
+		return HexCharset
+	}
+	return Bech32Charset
+}
+
+// encodedLen returns the number of data characters that n address bytes
+// take up in the given encoding, including the bech32 checksum
+func encodedLen(n int, encoding string) int {
+	if encoding == EncodingHex {
+		return 2 * n
+	}
+	return (8*n+4)/5 + 6
+}
+
+// objectSource draws random seeds and derives the named object address each
+// would create for a fixed creator. No keys are involved.
+type objectSource struct {
+	g        *Generator
+	creator  []byte
+	display  string
+	encoding string
+}
+
+func (s objectSource) next(yield yieldFunc) error {
+	raw, err := s.g.entropy(objectSeedBytes)
+	if err != nil {
+		return err
+	}
+	// The seed is kept printable so it can be pasted into a b"..." literal
+	seed := hex.EncodeToString(raw)
+
+	addr := ObjectAddress(s.creator, []byte(seed))
+	address, err := encodeAddress(addr, s.encoding)
+	if err != nil {
+		return err
+	}
+	yield(candidate{address: address, fill: func(r *Result) {
+		r.Seed = seed
+		r.Creator = s.display
+		r.HexAddress, _ = encodeAddress(addr, EncodingHex)
+		r.Bech32Address, _ = encodeAddress(addr, EncodingBech32)
+	}})
+	return nil
+}
+
+func (s objectSource) alphabet() string {
+	return encodingAlphabet(s.encoding)
+}
+
+func (s objectSource) dataLen() int {
+	return encodedLen(32, s.encoding)
+}
+
+// SetObjectCreator switches the generator from keys to Move named objects:
+// candidates are random seeds, matched on the object address they produce
+// for creator in the given encoding. Results carry the seed to pass to
+// object::create_named_object. It must be called before Generate.
+func (g *Generator) SetObjectCreator(creator string, encoding string) error {
+	addr, err := ParseMoveAddress(creator)
+	if err != nil {
+		return err
+	}
+	g.setSource(objectSource{g: g, creator: addr, display: creator, encoding: encoding})
+	return nil
+}
@@ -0,0 +1,120 @@
+package vanity
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func TestParseMoveAddress(t *testing.T) {
+	account := make([]byte, 32)
+	for i := 0; i < 20; i++ {
+		account[12+i] = byte(i + 1)
+	}
+	one := make([]byte, 32)
+	one[31] = 1
+
+	tests := []struct {
+		name    string
+		address string
+		want    []byte
+		wantErr bool
+	}{
+		{"bech32 account", "init1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc535vdd7", account, false},
+		{"short hex", "0x1", one, false},
+		{"padded hex", "0x" + hex.EncodeToString(account), account, false},
+		{"wrong prefix", "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", nil, true},
+		{"bad checksum", "init1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc535vdd8", nil, true},
+		{"bad hex", "0xzz", nil, true},
+		{"too long", "0x" + strings.Repeat("ab", 33), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoveAddress(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMoveAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(got, tt.want) {
+				t.Errorf("ParseMoveAddress() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectAddress(t *testing.T) {
+	creator, _ := ParseMoveAddress("0x1")
+	seed := []byte("vanity")
+
+	want := sha3.Sum256(append(append(append([]byte{}, creator...), seed...), 0xFE))
+	if got := ObjectAddress(creator, seed); !bytes.Equal(got, want[:]) {
+		t.Errorf("ObjectAddress() = %x, want %x", got, want)
+	}
+}
+
+func TestObjectSource(t *testing.T) {
+	for _, encoding := range []string{EncodingBech32, EncodingHex} {
+		t.Run(encoding, func(t *testing.T) {
+			creator, _ := ParseMoveAddress("0x1")
+			src := objectSource{g: &Generator{}, creator: creator, display: "0x1", encoding: encoding}
+
+			var address string
+			var result Result
+			err := src.next(func(c candidate) bool {
+				address = c.address
+				c.fill(&result)
+				return true
+			})
+			if err != nil {
+				t.Fatalf("next() error = %v", err)
+			}
+
+			want, _ := encodeAddress(ObjectAddress(creator, []byte(result.Seed)), encoding)
+			if address != want {
+				t.Errorf("address = %s, want %s for seed %s", address, want, result.Seed)
+			}
+			if address != result.HexAddress && address != result.Bech32Address {
+				t.Errorf("address %s missing from both forms %s and %s", address, result.HexAddress, result.Bech32Address)
+			}
+			if len(dataPart(address)) != src.dataLen() {
+				t.Errorf("data length = %d, want %d", len(dataPart(address)), src.dataLen())
+			}
+			for _, c := range dataPart(address) {
+				if !strings.ContainsRune(src.alphabet(), c) {
+					t.Errorf("address %s uses %q outside its alphabet", address, c)
+				}
+			}
+			if result.Creator != "0x1" || result.PrivateKey != "" {
+				t.Errorf("unexpected result fields: %+v", result)
+			}
+		})
+	}
+}
+
+func TestGenerateObject(t *testing.T) {
+	g := NewGenerator("c?f", "start", false, 2, false, "")
+	if err := g.SetObjectCreator("0x1", EncodingHex); err != nil {
+		t.Fatalf("SetObjectCreator() error = %v", err)
+	}
+	if got, want := g.Difficulty(), 256.0; got != want {
+		t.Errorf("Difficulty() = %v, want %v", got, want)
+	}
+
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	creator, _ := ParseMoveAddress("0x1")
+	for _, result := range g.GetResults() {
+		if !strings.HasPrefix(result.Address, "0xc") || result.Address[4] != 'f' {
+			t.Errorf("address does not match c?f: %s", result.Address)
+		}
+		want, _ := encodeAddress(ObjectAddress(creator, []byte(result.Seed)), EncodingHex)
+		if result.Address != want {
+			t.Errorf("address %s is not the object address of seed %s", result.Address, result.Seed)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package vanity
+
+import (
+	"math"
+	"strings"
+)
+
+// addressDataLen is the number of bech32 data characters in an account
+// address: 32 for the 20-byte payload plus 6 for the checksum
+const addressDataLen = 38
+
+// Pattern is a single search target: the text to look for, where it must
+// appear in the address and how many matching addresses to collect for it.
+// A Count of zero places no limit on the pattern beyond the generator's total.
+type Pattern struct {
+	Value    string `json:"value"`
+	Position string `json:"position"`
+	Count    int    `json:"count"`
+}
+
+// window is a fixed-length pattern that is tested against one window of an
+// address at a time
+type window interface {
+	// size returns the number of characters the pattern spans
+	size() int
+	// prefix returns the pattern cut down to its first n characters
+	prefix(n int) window
+	// suffix returns the pattern cut down to its last n characters
+	suffix(n int) window
+	// matchAt reports whether the pattern matches data starting at start
+	matchAt(data string, start int) bool
+	// probability returns the chance that a random window of characters
+	// from alphabet matches
+	probability(alphabet string) float64
+}
+
+// ValidatePattern checks that a pattern value is well-formed
+func ValidatePattern(value string) error {
+	_, err := compileWindow(value, Bech32Charset)
+	return err
+}
+
+// compileWindow compiles a pattern value into a window, either a named
+// pattern class such as "repeat:5" or a wildcard pattern. alphabet is the
+// set of characters the searched addresses are written in.
+func compileWindow(value, alphabet string) (window, error) {
+	if name, arg, ok := strings.Cut(value, ":"); ok {
+		return compileClass(name, arg, alphabet)
+	}
+	return compileWildcard(value, alphabet)
+}
+
+// isLiteral reports whether a pattern value is a plain string that can be
+// matched by the Aho-Corasick automaton
+func isLiteral(value string) bool {
+	return !strings.ContainsAny(value, ":?[{")
+}
+
+// dataPart returns the part of an address that patterns are matched against,
+// i.e. everything after the bech32 separator or the 0x prefix of a hex address
+func dataPart(address string) string {
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return address[2:]
+	}
+	if i := strings.LastIndexByte(address, '1'); i >= 0 {
+		return address[i+1:]
+	}
+	return address
+}
+
+// matchesAt reports whether an occurrence of length n starting at offset
+// start inside data satisfies the given position
+func matchesAt(position string, start, n, dataLen int) bool {
+	switch position {
+	case "start":
+		return start == 0
+	case "end":
+		return start+n == dataLen
+	case "any":
+		return true
+	default:
+		return false
+	}
+}
+
+// findWindow returns the offset in data at which w matches at the given
+// position
+func findWindow(w window, position string, data string) (int, bool) {
+	switch position {
+	case "start":
+		return 0, w.matchAt(data, 0)
+	case "end":
+		start := len(data) - w.size()
+		return start, w.matchAt(data, start)
+	case "any":
+		for start := 0; start+w.size() <= len(data); start++ {
+			if w.matchAt(data, start) {
+				return start, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// positionProbability returns the chance that a random address with dataLen
+// data characters from alphabet matches w at the given position
+func positionProbability(w window, position string, alphabet string, dataLen int) float64 {
+	p := w.probability(alphabet)
+	switch position {
+	case "start", "end":
+		return p
+	case "any":
+		windows := dataLen - w.size() + 1
+		if windows < 1 {
+			return 0
+		}
+		return 1 - math.Pow(1-p, float64(windows))
+	default:
+		return 0
+	}
+}
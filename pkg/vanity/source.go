@@ -0,0 +1,117 @@
+package vanity
+
+import "github.com/cosmos/cosmos-sdk/types/bech32"
+
+// candidate is one address produced by a source
+type candidate struct {
+	// address is the address patterns are matched against
+	address string
+	// also is another encoding of the same key that must match the patterns
+	// too, or empty
+	also string
+	// fill fills in the rest of the result, called only if the address is
+	// kept
+	fill func(*Result)
+}
+
+// yieldFunc receives a candidate and returns false when no more candidates
+// are wanted
+type yieldFunc func(c candidate) bool
+
+// source produces the candidate addresses a search draws from
+type source interface {
+	// next generates fresh candidates and passes each one to yield
+	next(yield yieldFunc) error
+	// alphabet returns the characters the address data is written in
+	alphabet() string
+	// dataLen returns the number of data characters in an address
+	dataLen() int
+}
+
+// Human-readable prefixes of Initia's bech32 addresses
+const (
+	AccountHRP = "init"
+	ValoperHRP = "initvaloper"
+	ValconsHRP = "initvalcons"
+)
+
+// keySource generates account keys, optionally through a BIP39 mnemonic, and
+// encodes their addresses with hrp. With a prefix other than AccountHRP the
+// init1 form is kept in the result, and must match as well if matchAccount
+// is set.
+type keySource struct {
+	g            *Generator
+	hrp          string
+	matchAccount bool
+}
+
+func (s keySource) next(yield yieldFunc) error {
+	if s.g.useMnemonic {
+		address, privKey, pubKey, mnemonic, derivationPath, err := s.g.generateAddressFromMnemonic()
+		if err != nil {
+			return err
+		}
+		return s.yield(yield, address, func(r *Result) {
+			r.PrivateKey = privKey
+			r.PublicKey = pubKey
+			r.Mnemonic = mnemonic
+			r.Language = s.g.mnemonicLanguage()
+			r.DerivationPath = derivationPath
+		})
+	}
+
+	address, privKey, pubKey, err := s.g.generateAddress()
+	if err != nil {
+		return err
+	}
+	return s.yield(yield, address, func(r *Result) {
+		r.PrivateKey = privKey
+		r.PublicKey = pubKey
+	})
+}
+
+// yield re-encodes an account address with the source's prefix and passes
+// it on
+func (s keySource) yield(yield yieldFunc, account string, fill func(*Result)) error {
+	if s.hrp == "" || s.hrp == AccountHRP {
+		yield(candidate{address: account, fill: fill})
+		return nil
+	}
+
+	_, raw, err := bech32.DecodeAndConvert(account)
+	if err != nil {
+		return err
+	}
+	address, err := bech32.ConvertAndEncode(s.hrp, raw)
+	if err != nil {
+		return err
+	}
+
+	c := candidate{address: address, fill: func(r *Result) {
+		fill(r)
+		r.AccountAddress = account
+	}}
+	if s.matchAccount {
+		c.also = account
+	}
+	yield(c)
+	return nil
+}
+
+func (s keySource) alphabet() string {
+	return Bech32Charset
+}
+
+func (s keySource) dataLen() int {
+	return addressDataLen
+}
+
+// SetValoper switches the generator to validator operator addresses: keys
+// are matched on their initvaloper1 encoding, and with matchAccount their
+// init1 account encoding must match as well. Both forms are reported. The
+// two forms share their data characters but not their checksum, so with
+// matchAccount a literal pattern at the end of the address never matches.
+// It must be called before Generate.
+func (g *Generator) SetValoper(matchAccount bool) {
+	g.setSource(keySource{g: g, hrp: ValoperHRP, matchAccount: matchAccount})
+}
@@ -0,0 +1,86 @@
+package vanity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+func TestKeySource(t *testing.T) {
+	tests := []struct {
+		name         string
+		hrp          string
+		matchAccount bool
+	}{
+		{"account", AccountHRP, false},
+		{"valoper", ValoperHRP, false},
+		{"valoper matching account", ValoperHRP, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator("q", "start", false, 1, false, "")
+			src := keySource{g: g, hrp: tt.hrp, matchAccount: tt.matchAccount}
+
+			var got candidate
+			if err := src.next(func(c candidate) bool {
+				got = c
+				return true
+			}); err != nil {
+				t.Fatalf("next() error = %v", err)
+			}
+			var result Result
+			got.fill(&result)
+
+			if !strings.HasPrefix(got.address, tt.hrp+"1") {
+				t.Errorf("address %s does not start with %s1", got.address, tt.hrp)
+			}
+			if result.PrivateKey == "" || result.PublicKey == "" {
+				t.Error("result is missing its keys")
+			}
+			if (got.also != "") != tt.matchAccount {
+				t.Errorf("also = %q, want it set only when matching the account", got.also)
+			}
+			if tt.hrp == AccountHRP {
+				if result.AccountAddress != "" {
+					t.Errorf("account address repeated for an account search: %s", result.AccountAddress)
+				}
+				return
+			}
+
+			// Both forms encode the same bytes
+			_, operator, _ := bech32.DecodeAndConvert(got.address)
+			hrp, account, err := bech32.DecodeAndConvert(result.AccountAddress)
+			if err != nil || hrp != AccountHRP || string(account) != string(operator) {
+				t.Errorf("account address %s does not match operator address %s", result.AccountAddress, got.address)
+			}
+		})
+	}
+}
+
+func TestGenerateValoper(t *testing.T) {
+	g := NewGenerator("q", "start", false, 2, false, "")
+	g.SetValoper(true)
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	results := g.GetResults()
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if !strings.HasPrefix(result.Address, "initvaloper1q") {
+			t.Errorf("operator address does not match: %s", result.Address)
+		}
+		if !strings.HasPrefix(result.AccountAddress, "init1q") {
+			t.Errorf("account address does not match: %s", result.AccountAddress)
+		}
+		// The forms differ only in their prefix and checksum
+		operator, account := dataPart(result.Address), dataPart(result.AccountAddress)
+		if operator[:len(operator)-6] != account[:len(account)-6] {
+			t.Errorf("forms differ outside the checksum: %s, %s", result.Address, result.AccountAddress)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package vanity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// charClass is the set of bytes accepted at one position of a wildcard
+type charClass [256]bool
+
+// wildcard is a compiled glob-style pattern with one character class per
+// position. Plain literals compile to a wildcard of single-byte classes.
+type wildcard []charClass
+
+// compileWildcard parses a pattern value. Supported syntax:
+//
+//	?        any character of alphabet
+//	[acde]   any of the listed characters
+//	[0-9]    any character in the range
+//	{n}      repeat the previous element n times in total
+//
+// Any other character matches itself.
+func compileWildcard(value, alphabet string) (wildcard, error) {
+	var w wildcard
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '?':
+			var class charClass
+			for j := 0; j < len(alphabet); j++ {
+				class[alphabet[j]] = true
+			}
+			w = append(w, class)
+		case '[':
+			end := strings.IndexByte(value[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in pattern '%s'", value)
+			}
+			class, err := parseClass(value[i+1 : i+end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid class in pattern '%s': %v", value, err)
+			}
+			w = append(w, class)
+			i += end
+		case '{':
+			end := strings.IndexByte(value[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '{' in pattern '%s'", value)
+			}
+			if len(w) == 0 {
+				return nil, fmt.Errorf("repetition without a preceding element in pattern '%s'", value)
+			}
+			n, err := strconv.Atoi(value[i+1 : i+end])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid repetition count in pattern '%s'", value)
+			}
+			last := w[len(w)-1]
+			for j := 1; j < n; j++ {
+				w = append(w, last)
+			}
+			i += end
+		case ']', '}':
+			return nil, fmt.Errorf("unexpected '%c' in pattern '%s'", c, value)
+		default:
+			var class charClass
+			class[c] = true
+			w = append(w, class)
+		}
+	}
+	return w, nil
+}
+
+// parseClass parses the body of a [...] character class
+func parseClass(body string) (charClass, error) {
+	var class charClass
+	if body == "" {
+		return class, fmt.Errorf("empty class")
+	}
+	for i := 0; i < len(body); i++ {
+		if i+2 < len(body) && body[i+1] == '-' {
+			lo, hi := body[i], body[i+2]
+			if lo > hi {
+				return class, fmt.Errorf("invalid range %c-%c", lo, hi)
+			}
+			for c := int(lo); c <= int(hi); c++ {
+				class[c] = true
+			}
+			i += 2
+			continue
+		}
+		class[body[i]] = true
+	}
+	return class, nil
+}
+
+func (w wildcard) size() int {
+	return len(w)
+}
+
+func (w wildcard) prefix(n int) window {
+	return w[:n]
+}
+
+func (w wildcard) suffix(n int) window {
+	return w[len(w)-n:]
+}
+
+func (w wildcard) matchAt(data string, start int) bool {
+	if start < 0 || start+len(w) > len(data) {
+		return false
+	}
+	for i := range w {
+		if !w[i][data[start+i]] {
+			return false
+		}
+	}
+	return true
+}
+
+func (w wildcard) probability(alphabet string) float64 {
+	p := 1.0
+	for i := range w {
+		n := 0
+		for j := 0; j < len(alphabet); j++ {
+			if w[i][alphabet[j]] {
+				n++
+			}
+		}
+		p *= float64(n) / float64(len(alphabet))
+	}
+	return p
+}
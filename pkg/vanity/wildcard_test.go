@@ -0,0 +1,107 @@
+package vanity
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompileWildcard(t *testing.T) {
+	tests := []struct {
+		pattern string
+		size    int
+		wantErr bool
+	}{
+		{pattern: "dao", size: 3},
+		{pattern: "dao??", size: 5},
+		{pattern: "[02468]{5}", size: 5},
+		{pattern: "[a-d]x{3}?", size: 5},
+		{pattern: "[acde", wantErr: true},
+		{pattern: "{3}", wantErr: true},
+		{pattern: "a{0}", wantErr: true},
+		{pattern: "a{x}", wantErr: true},
+		{pattern: "[]", wantErr: true},
+		{pattern: "[z-a]", wantErr: true},
+		{pattern: "a]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			w, err := compileWildcard(tt.pattern, Bech32Charset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compileWildcard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && w.size() != tt.size {
+				t.Errorf("size() = %d, want %d", w.size(), tt.size)
+			}
+		})
+	}
+}
+
+func TestWildcardMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		position string
+		address  string
+		want     bool
+	}{
+		{pattern: "dao??", position: "start", address: "init1daoxyqqq", want: true},
+		{pattern: "dao??", position: "start", address: "init1daxoyqqq", want: false},
+		{pattern: "[02468]{5}", position: "end", address: "init1qqq02468", want: true},
+		{pattern: "[02468]{5}", position: "end", address: "init1qqq02469", want: false},
+		{pattern: "[0-9]{3}", position: "any", address: "init1qq907qq", want: true},
+		{pattern: "[acde]x", position: "any", address: "init1qqqdxqq", want: true},
+		{pattern: "[acde]x", position: "any", address: "init1qqqfxqq", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.address, func(t *testing.T) {
+			g := NewGenerator(tt.pattern, tt.position, false, 1, false, "")
+			if got := g.isMatch(tt.address); got != tt.want {
+				t.Errorf("isMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDifficulty(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		position string
+		want     float64
+	}{
+		{name: "literal", pattern: "dew", position: "start", want: 32 * 32 * 32},
+		{name: "wildcard", pattern: "dew??", position: "end", want: 32 * 32 * 32},
+		{name: "class", pattern: "[02468]{2}", position: "start", want: 32.0 * 32 / 25},
+		{name: "digit range skips 1", pattern: "[0-9]", position: "start", want: 32.0 / 9},
+		{name: "invalid character", pattern: "bob", position: "end", want: math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator(tt.pattern, tt.position, false, 1, false, "")
+			if got := g.Difficulty(); math.Abs(got-tt.want) > 1e-6*tt.want {
+				t.Errorf("Difficulty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Anywhere in the address is easier than at a fixed position
+	start := NewGenerator("dew", "start", false, 1, false, "").Difficulty()
+	anywhere := NewGenerator("dew", "any", false, 1, false, "").Difficulty()
+	if anywhere >= start/30 {
+		t.Errorf("expected 'any' difficulty %v to be about 36x below 'start' %v", anywhere, start)
+	}
+}
+
+func TestGenerateWildcard(t *testing.T) {
+	g := NewGenerator("[02468]?", "end", false, 2, false, "")
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, result := range g.GetResults() {
+		if !g.isMatch(result.Address) {
+			t.Errorf("generated address does not match pattern: %s", result.Address)
+		}
+	}
+}
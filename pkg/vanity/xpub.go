@@ -0,0 +1,188 @@
+package vanity
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/cosmos/btcutil/base58"
+	sdksecp256k1 "github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// XPubPath is the path of the extended public key a watch-only search
+// derives from. Its non-hardened children are the account addresses at
+// m/44'/118'/0'/0/index.
+const XPubPath = "m/44'/118'/0'/0"
+
+// xpubDepth is the depth of XPubPath
+const xpubDepth = 4
+
+// hardened is the first hardened child index
+const hardened = 1 << 31
+
+// Version bytes of serialized mainnet extended keys
+var (
+	xpubVersion = []byte{0x04, 0x88, 0xb2, 0x1e}
+	xprvVersion = []byte{0x04, 0x88, 0xad, 0xe4}
+)
+
+// ExtendedPubKey is a BIP32 extended public key
+type ExtendedPubKey struct {
+	// Key is the 33-byte compressed public key
+	Key []byte
+	// ChainCode is the 32-byte chain code
+	ChainCode []byte
+	// Depth is the number of derivation steps from the master key
+	Depth byte
+
+	point secp256k1.JacobianPoint
+}
+
+// NewExtendedPubKey returns the extended public key for a compressed public
+// key and chain code at XPubPath
+func NewExtendedPubKey(key, chainCode []byte) (*ExtendedPubKey, error) {
+	if len(chainCode) != 32 {
+		return nil, fmt.Errorf("chain code must be 32 bytes, got %d", len(chainCode))
+	}
+	pubKey, err := secp256k1.ParsePubKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+
+	k := &ExtendedPubKey{
+		Key:       pubKey.SerializeCompressed(),
+		ChainCode: append([]byte(nil), chainCode...),
+		Depth:     xpubDepth,
+	}
+	pubKey.AsJacobian(&k.point)
+	return k, nil
+}
+
+// ParseExtendedPubKey parses a base58 xpub
+func ParseExtendedPubKey(xpub string) (*ExtendedPubKey, error) {
+	raw := base58.Decode(xpub)
+	if len(raw) != 82 {
+		return nil, fmt.Errorf("invalid xpub: expected 82 bytes, got %d", len(raw))
+	}
+	payload, sum := raw[:78], raw[78:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], sum) {
+		return nil, fmt.Errorf("invalid xpub: bad checksum")
+	}
+
+	switch {
+	case bytes.Equal(payload[:4], xprvVersion):
+		return nil, fmt.Errorf("extended key is private; pass the xpub instead")
+	case !bytes.Equal(payload[:4], xpubVersion):
+		return nil, fmt.Errorf("invalid xpub: unknown version %x", payload[:4])
+	}
+
+	k, err := NewExtendedPubKey(payload[45:78], payload[13:45])
+	if err != nil {
+		return nil, err
+	}
+	k.Depth = payload[4]
+	return k, nil
+}
+
+// Child derives the compressed public key of a non-hardened child. A child
+// index whose key is invalid, which happens with probability below 2^-127,
+// returns an error, and BIP32 moves on to the next index.
+func (k *ExtendedPubKey) Child(index uint32) ([]byte, error) {
+	if index >= hardened {
+		return nil, fmt.Errorf("child %d is hardened and needs the private key", index)
+	}
+
+	mac := hmac.New(sha512.New, k.ChainCode)
+	mac.Write(k.Key)
+	binary.Write(mac, binary.BigEndian, index)
+	sum := mac.Sum(nil)
+
+	var tweak secp256k1.ModNScalar
+	if overflow := tweak.SetByteSlice(sum[:32]); overflow {
+		return nil, fmt.Errorf("child %d is invalid", index)
+	}
+
+	var point, child secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&tweak, &point)
+	secp256k1.AddNonConst(&point, &k.point, &child)
+	if (child.X.IsZero() && child.Y.IsZero()) || child.Z.IsZero() {
+		return nil, fmt.Errorf("child %d is invalid", index)
+	}
+	child.ToAffine()
+	return secp256k1.NewPublicKey(&child.X, &child.Y).SerializeCompressed(), nil
+}
+
+// xpubSource walks the non-hardened children of an extended public key in
+// order, shared between all workers
+type xpubSource struct {
+	key     *ExtendedPubKey
+	counter *atomic.Uint64
+}
+
+func (s xpubSource) nextIndex() (uint32, error) {
+	index := s.counter.Add(1) - 1
+	if index >= hardened {
+		return 0, fmt.Errorf("searched every non-hardened child index")
+	}
+	return uint32(index), nil
+}
+
+func (s xpubSource) next(yield yieldFunc) error {
+	index, err := s.nextIndex()
+	if err != nil {
+		return err
+	}
+	key, err := s.key.Child(index)
+	if err != nil {
+		// Skip invalid children as wallets do
+		return nil
+	}
+
+	pubKey := &sdksecp256k1.PubKey{Key: key}
+	address, err := bech32.ConvertAndEncode(AccountHRP, pubKey.Address())
+	if err != nil {
+		return err
+	}
+
+	yield(candidate{address: address, fill: func(r *Result) {
+		// Encoding a map of strings cannot fail
+		pubKeyJSON, _ := json.Marshal(map[string]interface{}{
+			"@type": Secp256k1PubKeyType,
+			"key":   base64.StdEncoding.EncodeToString(key),
+		})
+		r.PublicKey = string(pubKeyJSON)
+		r.Index = &index
+		r.DerivationPath = fmt.Sprintf("%s/%d", XPubPath, index)
+	}})
+	return nil
+}
+
+func (xpubSource) alphabet() string {
+	return Bech32Charset
+}
+
+func (xpubSource) dataLen() int {
+	return addressDataLen
+}
+
+// SetExtendedPubKey switches the generator to a watch-only search over the
+// non-hardened children of an extended public key at XPubPath, from index 0
+// upwards. No private key is generated; results report the child index. It
+// must be called before Generate.
+func (g *Generator) SetExtendedPubKey(key *ExtendedPubKey) error {
+	if key.Depth != xpubDepth {
+		return fmt.Errorf("extended key is at depth %d; export the key at %s", key.Depth, XPubPath)
+	}
+	g.setSource(xpubSource{key: key, counter: new(atomic.Uint64)})
+	return nil
+}
@@ -0,0 +1,152 @@
+package vanity
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdksecp256k1 "github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// BIP32 test vector 1 keys at m/0'/1/2' and m/0'/1/2'/2
+const (
+	vectorSeed   = "000102030405060708090a0b0c0d0e0f"
+	vectorParent = "xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5"
+	vectorChild  = "xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV"
+	vectorMaster = "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"
+)
+
+func TestParseExtendedPubKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		xpub      string
+		wantDepth byte
+		wantErr   bool
+	}{
+		{"vector parent", vectorParent, 3, false},
+		{"vector child", vectorChild, 4, false},
+		{"bad checksum", vectorParent[:len(vectorParent)-1] + "6", 0, true},
+		{"private key", vectorMaster, 0, true},
+		{"not base58", "init1dew", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseExtendedPubKey(tt.xpub)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExtendedPubKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && key.Depth != tt.wantDepth {
+				t.Errorf("depth = %d, want %d", key.Depth, tt.wantDepth)
+			}
+		})
+	}
+}
+
+func TestExtendedPubKeyChild(t *testing.T) {
+	parent, err := ParseExtendedPubKey(vectorParent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ParseExtendedPubKey(vectorChild)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	child, err := parent.Child(2)
+	if err != nil {
+		t.Fatalf("Child() error = %v", err)
+	}
+	if !bytes.Equal(child, want.Key) {
+		t.Errorf("Child(2) = %x, want %x", child, want.Key)
+	}
+
+	// Public derivation must agree with private derivation
+	seed, _ := hex.DecodeString(vectorSeed)
+	master, chainCode := hd.ComputeMastersFromSeed(seed)
+	privKey, err := hd.DerivePrivateKeyForPath(master, chainCode, "m/0'/1/2'/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if derived := (&sdksecp256k1.PrivKey{Key: privKey}).PubKey().Bytes(); !bytes.Equal(child, derived) {
+		t.Errorf("Child(2) = %x, private derivation gives %x", child, derived)
+	}
+
+	if _, err := parent.Child(hardened); err == nil {
+		t.Error("Child() derived a hardened child")
+	}
+}
+
+func TestNewExtendedPubKey(t *testing.T) {
+	parent, _ := ParseExtendedPubKey(vectorParent)
+
+	key, err := NewExtendedPubKey(parent.Key, parent.ChainCode)
+	if err != nil {
+		t.Fatalf("NewExtendedPubKey() error = %v", err)
+	}
+	if key.Depth != xpubDepth {
+		t.Errorf("depth = %d, want %d", key.Depth, xpubDepth)
+	}
+	if _, err := NewExtendedPubKey(parent.Key, parent.ChainCode[:31]); err == nil {
+		t.Error("NewExtendedPubKey() accepted a short chain code")
+	}
+	if _, err := NewExtendedPubKey(parent.Key[1:], parent.ChainCode); err == nil {
+		t.Error("NewExtendedPubKey() accepted an invalid key")
+	}
+}
+
+func TestGenerateExtendedPubKey(t *testing.T) {
+	g := NewGenerator("q", "start", false, 2, false, "")
+	parent, _ := ParseExtendedPubKey(vectorParent)
+	if err := g.SetExtendedPubKey(parent); err == nil {
+		t.Error("SetExtendedPubKey() accepted a key at depth 3")
+	}
+
+	key, _ := NewExtendedPubKey(parent.Key, parent.ChainCode)
+	if err := g.SetExtendedPubKey(key); err != nil {
+		t.Fatalf("SetExtendedPubKey() error = %v", err)
+	}
+	if err := g.Generate(2); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, result := range g.GetResults() {
+		if result.PrivateKey != "" {
+			t.Errorf("watch-only result has a private key: %+v", result)
+		}
+		if result.Index == nil {
+			t.Fatalf("result has no index: %+v", result)
+		}
+		if want := fmt.Sprintf("%s/%d", XPubPath, *result.Index); result.DerivationPath != want {
+			t.Errorf("derivation path = %s, want %s", result.DerivationPath, want)
+		}
+
+		child, err := key.Child(*result.Index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		address, _ := bech32.ConvertAndEncode(AccountHRP, (&sdksecp256k1.PubKey{Key: child}).Address())
+		if address != result.Address || !strings.HasPrefix(address, "init1q") {
+			t.Errorf("index %d has address %s, result says %s", *result.Index, address, result.Address)
+		}
+	}
+}
+
+func TestXPubSourceExhausted(t *testing.T) {
+	parent, _ := ParseExtendedPubKey(vectorParent)
+	key, _ := NewExtendedPubKey(parent.Key, parent.ChainCode)
+
+	counter := new(atomic.Uint64)
+	counter.Store(hardened)
+	g := NewGenerator("q", "start", false, 1, false, "")
+	g.setSource(xpubSource{key: key, counter: counter})
+
+	if err := g.Generate(2); err == nil {
+		t.Error("Generate() did not report running out of indexes")
+	}
+}